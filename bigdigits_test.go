@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBigDigitsView(t *testing.T) {
+	t.Run("カウンターを端末の大きさに合わせて表示", func(t *testing.T) {
+		m := NewCounterModel()
+		m.count = -12
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+		view := newModel.View()
+		if !strings.Contains(view, "###") || strings.Contains(view, "カウンター: -12") {
+			t.Errorf("大きな数字で表示されるべき:\n%s", view)
+		}
+	})
+
+	t.Run("狭い端末では通常の表示", func(t *testing.T) {
+		m := NewCounterModel()
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 6, Height: 40})
+
+		if view := newModel.View(); strings.Contains(view, "###") || !strings.Contains(view, "カウンター: 0") {
+			t.Errorf("通常の表示になるべき:\n%s", view)
+		}
+	})
+
+	t.Run("低い端末では通常の表示", func(t *testing.T) {
+		m := NewCounterModel()
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 12})
+
+		if view := newModel.View(); strings.Contains(view, "###") {
+			t.Errorf("高さが足りなければ通常の表示になるべき:\n%s", view)
+		}
+	})
+
+	t.Run("タイマーは高さに収まる大きさで表示", func(t *testing.T) {
+		m, _ := newFakeClockTimer()
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+
+		view := newModel.View()
+		if !strings.Contains(view, "###") {
+			t.Errorf("大きな数字で表示されるべき:\n%s", view)
+		}
+		if h := strings.Count(view, "\n") + 1; h > 24 {
+			t.Errorf("端末の高さに収まるべき、実際: %d行", h)
+		}
+	})
+
+	t.Run("一覧は選択中のタイマーを大きく表示", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+		view := newModel.View()
+		if !strings.Contains(view, "###") || !strings.Contains(view, "タイマー1") {
+			t.Errorf("大きな数字と一覧が表示されるべき:\n%s", view)
+		}
+		if h := strings.Count(view, "\n") + 1; h > 40 {
+			t.Errorf("端末の高さに収まるべき、実際: %d行", h)
+		}
+	})
+}
@@ -2,21 +2,89 @@ package main
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/ktny/bubbletea-learning/pkg/common"
 	"github.com/ktny/bubbletea-learning/pkg/constants"
 	"github.com/ktny/bubbletea-learning/pkg/styles"
 )
 
 type counterModel struct {
-	count int
+	count   int
+	name    string         // 一覧での名前
+	color   int            // 数値の色（styles.CounterColorsの番号+1、0の場合は値の符号で色分け）
+	daily   bool           // 日付が変わったらリセットするか
+	updated time.Time      // 最後に変更・リセットした時刻（日付が変わったかの判定に使う）
+	config  counterConfig  // 増減量と範囲
+	prefix  string         // 入力中の数値プレフィックス（vimの `10↑` のような回数指定）
+	history counterHistory // 変更履歴（元に戻す・やり直し用）
+	width   int            // 端末の幅（大きな数字の表示に使う、0の場合は通常の表示）
+	height  int            // 端末の高さ（0の場合は高さを制限しない）
 }
 
 func NewCounterModel() counterModel {
+	return NewCounterModelWithConfig(defaultCounterConfig())
+}
+
+// NewCounterModelWithConfig - 増減量と範囲を指定してカウンターを生成
+func NewCounterModelWithConfig(cfg counterConfig) counterModel {
+	count := cfg.initial()
 	return counterModel{
-		count: 0,
+		count:   count,
+		config:  cfg,
+		history: newCounterHistory(count),
+	}
+}
+
+// 数値プレフィックスの回数（未入力の場合は1）
+func (m counterModel) repeat() int {
+	if n, err := strconv.Atoi(m.prefix); err == nil && n > 0 {
+		return n
 	}
+	return 1
+}
+
+// カウントを変更して履歴に記録（値が変わらない場合は記録しない）
+func (m counterModel) setCount(v int) counterModel {
+	return m.setCountValue(big.NewInt(int64(v)))
+}
+
+// 範囲に収める前の値がintに収まらない場合もあるsetCount
+func (m counterModel) setCountValue(value *big.Int) counterModel {
+	v := m.config.boundValue(value)
+	m.prefix = ""
+	if v == m.count {
+		return m
+	}
+	m.count = v
+	m.history = m.history.push(v)
+	return m
+}
+
+// 増減量×回数だけ増減
+func (m counterModel) add(sign int) counterModel {
+	// 増減量×回数がintを超えても反対の符号に回り込まないよう多倍長整数で計算
+	delta := new(big.Int).Mul(big.NewInt(int64(sign*m.config.step)), big.NewInt(int64(m.repeat())))
+	return m.setCountValue(delta.Add(delta, big.NewInt(int64(m.count))))
+}
+
+// 元に戻す・やり直し
+func (m counterModel) moveHistory(undo bool) counterModel {
+	h, ok := m.history.redo()
+	if undo {
+		h, ok = m.history.undo()
+	}
+	m.prefix = ""
+	if ok {
+		m.history = h
+		m.count = h.current()
+	}
+	return m
 }
 
 func (m counterModel) Init() tea.Cmd {
@@ -25,6 +93,10 @@ func (m counterModel) Init() tea.Cmd {
 
 func (m counterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
 	case tea.KeyMsg:
 		// Handle common quit keys
 		if cmd := common.HandleQuitKeys(msg); cmd != nil {
@@ -33,18 +105,32 @@ func (m counterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		switch msg.Type {
 		case tea.KeyUp:
-			m.count++
-			return m, nil
+			return m.add(1), nil
 		case tea.KeyDown:
-			m.count--
-			return m, nil
+			return m.add(-1), nil
 		case tea.KeySpace:
-			m.count = 0
+			return m.setCount(m.config.initial()), nil
+		case tea.KeyCtrlR:
+			return m.moveHistory(false), nil
+		case tea.KeyEsc:
+			m.prefix = ""
 			return m, nil
 		case tea.KeyRunes:
-			switch string(msg.Runes) {
+			key := string(msg.Runes)
+			switch key {
+			case "+":
+				return m.add(1), nil
+			case "-":
+				return m.add(-1), nil
 			case "s": // Alternative space for reset
-				m.count = 0
+				return m.setCount(m.config.initial()), nil
+			case "u":
+				return m.moveHistory(true), nil
+			}
+			// 数字は回数の指定（先頭の0は無視）
+			if len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9' &&
+				(m.prefix != "" || key != "0") && len(m.prefix) < constants.CounterPrefixMaxDigits {
+				m.prefix += key
 				return m, nil
 			}
 		}
@@ -52,22 +138,73 @@ func (m counterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// カウンター数値のスタイル（色の指定がなければ値の符号で色分け）
+func (m counterModel) countStyle() lipgloss.Style {
+	if m.color > 0 {
+		return lipgloss.NewStyle().
+			Foreground(styles.CounterColors[(m.color-1)%len(styles.CounterColors)]).
+			Bold(true)
+	}
+	switch {
+	case m.count > 0:
+		return styles.CounterPositiveStyle
+	case m.count < 0:
+		return styles.CounterNegativeStyle
+	}
+	return styles.CounterZeroStyle
+}
+
 func (m counterModel) View() string {
-	// カウンター数値のスタイル（条件付き）
-	var countStyle = styles.CounterZeroStyle
-	if m.count > 0 {
-		countStyle = styles.CounterPositiveStyle
-	} else if m.count < 0 {
-		countStyle = styles.CounterNegativeStyle
+	countStyle := m.countStyle()
+
+	// 端末に収まれば大きな数字で表示
+	text := fmt.Sprintf("%d", m.count)
+	countText := "カウンター: " + countStyle.Render(text)
+	rows := 0
+	if m.height > 0 {
+		// 通常の表示から増える行（見出しと空行の2行）を除いた残り
+		rows = max(m.height-lipgloss.Height(m.render(countText))-1, 1)
+	}
+	available := m.width - styles.BorderStyle.GetHorizontalFrameSize()
+	if big, ok := styles.BigDigits(text, available, rows); ok {
+		countText = "カウンター:\n\n" + countStyle.Render(big)
 	}
+	return m.render(countText)
+}
+
+// カウント以下の補足情報（範囲・入力中の回数・値の推移）
+func (m counterModel) infoView() string {
+	var lines []string
+	if text := m.config.rangeText(); text != "" {
+		lines = append(lines, text)
+	}
+	if m.config.step != 1 {
+		lines = append(lines, fmt.Sprintf("増減量: %d", m.config.step))
+	}
+	if m.prefix != "" {
+		lines = append(lines, "回数: "+m.prefix)
+	}
+	if values := m.history.recent(constants.CounterSparklineWidth); len(values) > 1 {
+		lines = append(lines, "推移: "+sparkline(values))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\n" + styles.DimmedStyle.Render(strings.Join(lines, "\n"))
+}
 
+// カウントの表示を指定して描画
+func (m counterModel) render(countText string) string {
 	content := fmt.Sprintf(
-		"%s\n\nカウンター: %s\n\n%s",
+		"%s\n\n%s%s\n\n%s",
 		styles.TitleStyle.Render(constants.CounterTitle),
-		countStyle.Render(fmt.Sprintf("%d", m.count)),
+		countText,
+		m.infoView(),
 		styles.HelpStyle.Render(
-			"↑: 増加\n"+
-			"↓: 減少\n"+
+			"↑/+: 増加\n"+
+			"↓/-: 減少\n"+
+			"数字+↑/↓: 回数を指定して増減\n"+
+			"u/Ctrl+R: 元に戻す/やり直し\n"+
 			"スペース/s: リセット\n"+
 			constants.QuitHelp,
 		),
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// 上限・下限を超えたときの動作
+type counterOverflow int
+
+const (
+	overflowClamp counterOverflow = iota // 上限・下限で止める
+	overflowWrap                         // 反対側の端に回り込む
+)
+
+// カウンターの設定
+type counterConfig struct {
+	step     int             // 1回の増減量
+	min      *int            // 下限（nilの場合は制限しない）
+	max      *int            // 上限（nilの場合は制限しない）
+	overflow counterOverflow // 範囲を超えたときの動作
+}
+
+// デフォルトの設定（1ずつ増減、範囲の制限なし）
+func defaultCounterConfig() counterConfig {
+	return counterConfig{step: 1}
+}
+
+// 設定の検証
+func (c counterConfig) validate() error {
+	if c.step <= 0 {
+		return fmt.Errorf("増減量は1以上で指定してください: %d", c.step)
+	}
+	if c.min != nil && c.max != nil && *c.min > *c.max {
+		return fmt.Errorf("下限 %d が上限 %d より大きいです", *c.min, *c.max)
+	}
+	if c.overflow == overflowWrap && (c.min == nil || c.max == nil) {
+		return errors.New("回り込みには上限と下限の両方が必要です")
+	}
+	return nil
+}
+
+// 値を範囲に収める
+func (c counterConfig) bound(v int) int {
+	return c.boundValue(big.NewInt(int64(v)))
+}
+
+// 値を範囲に収める（範囲の大きさや範囲外の値がintに収まらない場合があるので多倍長整数で計算）
+func (c counterConfig) boundValue(v *big.Int) int {
+	if c.overflow == overflowWrap && c.min != nil && c.max != nil {
+		lo := big.NewInt(int64(*c.min))
+		size := new(big.Int).Sub(big.NewInt(int64(*c.max)), lo)
+		size.Add(size, big.NewInt(1))
+		// Modは除数が正なら0以上の余りを返す
+		offset := new(big.Int).Sub(v, lo)
+		offset.Mod(offset, size)
+		return int(offset.Add(offset, lo).Int64())
+	}
+	lo, hi := big.NewInt(math.MinInt), big.NewInt(math.MaxInt)
+	if c.min != nil {
+		lo = big.NewInt(int64(*c.min))
+	}
+	if c.max != nil {
+		hi = big.NewInt(int64(*c.max))
+	}
+	switch {
+	case v.Cmp(lo) < 0:
+		return int(lo.Int64())
+	case v.Cmp(hi) > 0:
+		return int(hi.Int64())
+	}
+	return int(v.Int64())
+}
+
+// リセット後の値（0を範囲の端で止めた値）
+func (c counterConfig) initial() int {
+	c.overflow = overflowClamp
+	return c.bound(0)
+}
+
+// 範囲の表示（制限がない場合は空）
+func (c counterConfig) rangeText() string {
+	if c.min == nil && c.max == nil {
+		return ""
+	}
+	lo, hi := "-∞", "∞"
+	if c.min != nil {
+		lo = strconv.Itoa(*c.min)
+	}
+	if c.max != nil {
+		hi = strconv.Itoa(*c.max)
+	}
+	text := fmt.Sprintf("範囲: %s〜%s", lo, hi)
+	if c.overflow == overflowWrap {
+		text += "（回り込み）"
+	}
+	return text
+}
+
+// intの値を指定された場合だけ設定するフラグ
+func optionalIntFlag(p **int) func(string) error {
+	return func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("整数ではありません: %q", s)
+		}
+		*p = &v
+		return nil
+	}
+}
+
+// コマンドラインで指定したフラグの名前
+type counterFlagSet map[string]bool
+
+// 保存済みの設定 base に、指定したフラグの分だけ cfg の値を上書きする
+func (set counterFlagSet) apply(base, cfg counterConfig) (counterConfig, error) {
+	if set["step"] {
+		base.step = cfg.step
+	}
+	if set["min"] {
+		base.min = cfg.min
+	}
+	if set["max"] {
+		base.max = cfg.max
+	}
+	if set["wrap"] {
+		base.overflow = cfg.overflow
+	}
+	return base, base.validate()
+}
+
+// `counter` サブコマンドの引数から設定と指定したフラグを読み込む
+func parseCounterConfig(args []string, w io.Writer) (counterConfig, counterFlagSet, error) {
+	cfg := defaultCounterConfig()
+	set := counterFlagSet{}
+	fs := flag.NewFlagSet("counter", flag.ContinueOnError)
+	fs.SetOutput(w)
+	fs.IntVar(&cfg.step, "step", cfg.step, "1回の増減量")
+	fs.Func("min", "下限", optionalIntFlag(&cfg.min))
+	fs.Func("max", "上限", optionalIntFlag(&cfg.max))
+	wrap := fs.Bool("wrap", false, "範囲を超えたら反対側の端に回り込む")
+	if err := fs.Parse(args); err != nil {
+		return cfg, set, err
+	}
+	if *wrap {
+		cfg.overflow = overflowWrap
+	}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return cfg, set, cfg.validate()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func sendCounterKey(m counterModel, key tea.KeyMsg) counterModel {
+	newModel, _ := m.Update(key)
+	return newModel.(counterModel)
+}
+
+func TestCounterConfig(t *testing.T) {
+	t.Run("上限・下限で止める", func(t *testing.T) {
+		cfg := counterConfig{step: 1, min: intPtr(-2), max: intPtr(3)}
+
+		for v, want := range map[int]int{-5: -2, 0: 0, 3: 3, 10: 3} {
+			if got := cfg.bound(v); got != want {
+				t.Errorf("bound(%d) = %d、期待値: %d", v, got, want)
+			}
+		}
+	})
+
+	t.Run("反対側の端に回り込む", func(t *testing.T) {
+		cfg := counterConfig{step: 1, min: intPtr(1), max: intPtr(5), overflow: overflowWrap}
+
+		for v, want := range map[int]int{6: 1, 7: 2, 0: 5, -4: 1, 3: 3} {
+			if got := cfg.bound(v); got != want {
+				t.Errorf("bound(%d) = %d、期待値: %d", v, got, want)
+			}
+		}
+	})
+
+	t.Run("intの全範囲で回り込む", func(t *testing.T) {
+		cfg := counterConfig{step: 1, min: intPtr(math.MinInt), max: intPtr(math.MaxInt), overflow: overflowWrap}
+		for v, want := range map[int]int{0: 0, math.MinInt: math.MinInt, math.MaxInt: math.MaxInt} {
+			if got := cfg.bound(v); got != want {
+				t.Errorf("bound(%d) = %d、期待値: %d", v, got, want)
+			}
+		}
+
+		cfg = counterConfig{step: 1, min: intPtr(math.MinInt), max: intPtr(0), overflow: overflowWrap}
+		if got := cfg.bound(math.MaxInt); got != -2 {
+			t.Errorf("範囲の大きさがintに収まらなくても回り込むべき、実際: %d", got)
+		}
+	})
+
+	t.Run("リセット後の値は範囲に収める", func(t *testing.T) {
+		cfg := counterConfig{step: 1, min: intPtr(10), max: intPtr(20), overflow: overflowWrap}
+		if got := cfg.initial(); got != 10 {
+			t.Errorf("0に近い端になるべき、実際: %d", got)
+		}
+	})
+
+	t.Run("引数の読み込み", func(t *testing.T) {
+		cfg, _, err := parseCounterConfig([]string{"--step", "5", "--min", "0", "--max", "100", "--wrap"}, io.Discard)
+		if err != nil {
+			t.Fatalf("エラーになるべきではない: %v", err)
+		}
+		if cfg.step != 5 || *cfg.min != 0 || *cfg.max != 100 || cfg.overflow != overflowWrap {
+			t.Errorf("指定した設定になるべき、実際: %+v", cfg)
+		}
+
+		cfg, _, err = parseCounterConfig(nil, io.Discard)
+		if err != nil || cfg.step != 1 || cfg.min != nil || cfg.max != nil {
+			t.Errorf("指定がなければデフォルトの設定になるべき、実際: %+v (%v)", cfg, err)
+		}
+	})
+
+	t.Run("不正な設定はエラー", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"--step", "0"},
+			{"--min", "5", "--max", "1"},
+			{"--max", "10", "--wrap"},
+			{"--min", "abc"},
+		} {
+			if _, _, err := parseCounterConfig(args, io.Discard); err == nil {
+				t.Errorf("%v はエラーになるべき", args)
+			}
+		}
+	})
+}
+
+func TestCounterStep(t *testing.T) {
+	t.Run("数値プレフィックスで回数を指定", func(t *testing.T) {
+		m := NewCounterModelWithConfig(counterConfig{step: 2})
+		m = sendCounterKey(m, runeKey("1"))
+		m = sendCounterKey(m, runeKey("0"))
+		if m.prefix != "10" {
+			t.Fatalf("数字が回数として入力されるべき、実際: %q", m.prefix)
+		}
+
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		if m.count != 20 || m.prefix != "" {
+			t.Errorf("増減量×回数だけ増えて回数はクリアされるべき、実際: %d (%q)", m.count, m.prefix)
+		}
+
+		m = sendCounterKey(m, runeKey("3"))
+		m = sendCounterKey(m, runeKey("-"))
+		if m.count != 14 {
+			t.Errorf("-キーでも回数を指定して減らせるべき、実際: %d", m.count)
+		}
+	})
+
+	t.Run("先頭の0とEscで回数はクリア", func(t *testing.T) {
+		m := NewCounterModel()
+		m = sendCounterKey(m, runeKey("0"))
+		if m.prefix != "" {
+			t.Errorf("先頭の0は無視されるべき、実際: %q", m.prefix)
+		}
+
+		m = sendCounterKey(m, runeKey("5"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+		m = sendCounterKey(m, runeKey("+"))
+		if m.count != 1 {
+			t.Errorf("Escで回数がクリアされるべき、実際: %d", m.count)
+		}
+	})
+
+	t.Run("範囲を超えると止まる", func(t *testing.T) {
+		m := NewCounterModelWithConfig(counterConfig{step: 1, min: intPtr(0), max: intPtr(3)})
+		m = sendCounterKey(m, runeKey("9"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		if m.count != 3 {
+			t.Errorf("上限で止まるべき、実際: %d", m.count)
+		}
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		if len(m.history.values) != 2 {
+			t.Errorf("値が変わらなければ履歴に記録されないべき、実際: %v", m.history.values)
+		}
+	})
+
+	t.Run("intの全範囲で回り込む", func(t *testing.T) {
+		cfg, _, err := parseCounterConfig([]string{"--min", strconv.Itoa(math.MinInt), "--max", strconv.Itoa(math.MaxInt), "--wrap"}, io.Discard)
+		if err != nil {
+			t.Fatalf("エラーになるべきではない: %v", err)
+		}
+		m := sendCounterKey(NewCounterModelWithConfig(cfg), tea.KeyMsg{Type: tea.KeyDown})
+		if m.count != -1 {
+			t.Errorf("範囲内はそのまま減るべき、実際: %d", m.count)
+		}
+	})
+
+	t.Run("intを超える増減は端で止める", func(t *testing.T) {
+		m := NewCounterModelWithConfig(counterConfig{step: math.MaxInt / 2})
+		m = sendCounterKey(m, runeKey("3"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		if m.count != math.MaxInt {
+			t.Errorf("intの最大値で止まるべき、実際: %d", m.count)
+		}
+		m = sendCounterKey(m, runeKey("9"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		if m.count != math.MinInt {
+			t.Errorf("intの最小値で止まるべき、実際: %d", m.count)
+		}
+
+		m = NewCounterModelWithConfig(counterConfig{step: math.MaxInt, min: intPtr(-5), max: intPtr(5), overflow: overflowWrap})
+		m = sendCounterKey(m, runeKey("2"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		// (2×MaxInt + 5) mod 11 - 5
+		if m.count != 3 {
+			t.Errorf("回り込みはintを超える値でも正しく計算するべき、実際: %d", m.count)
+		}
+	})
+
+	t.Run("範囲を超えると回り込む", func(t *testing.T) {
+		m := NewCounterModelWithConfig(counterConfig{step: 1, min: intPtr(0), max: intPtr(3), overflow: overflowWrap})
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		if m.count != 3 {
+			t.Errorf("下限の下は上限に回り込むべき、実際: %d", m.count)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// カウントの変更履歴（元に戻す・やり直し用）
+type counterHistory struct {
+	values []int // これまでの値（古い順）
+	pos    int   // 現在の値の位置（これより後はやり直しできる値）
+}
+
+// 初期値だけの履歴を生成
+func newCounterHistory(v int) counterHistory {
+	return counterHistory{values: []int{v}}
+}
+
+// 現在の値
+func (h counterHistory) current() int {
+	return h.values[h.pos]
+}
+
+// 値の変更を記録（やり直しできる値は破棄する）
+func (h counterHistory) push(v int) counterHistory {
+	values := append(h.values[:h.pos+1:h.pos+1], v)
+	if len(values) > constants.CounterHistoryMax {
+		values = values[len(values)-constants.CounterHistoryMax:]
+	}
+	return counterHistory{values: values, pos: len(values) - 1}
+}
+
+// 1つ前の値に戻す
+func (h counterHistory) undo() (counterHistory, bool) {
+	if h.pos == 0 {
+		return h, false
+	}
+	h.pos--
+	return h, true
+}
+
+// 元に戻した値をやり直す
+func (h counterHistory) redo() (counterHistory, bool) {
+	if h.pos == len(h.values)-1 {
+		return h, false
+	}
+	h.pos++
+	return h, true
+}
+
+// 現在までの値の推移（最大n個）
+func (h counterHistory) recent(n int) []int {
+	values := h.values[:h.pos+1]
+	return values[max(len(values)-n, 0):]
+}
+
+// スパークラインに使う文字（低い順）
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// 値の推移を1行のスパークラインで描画
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			// 値が大きいと差がintに収まらないので浮動小数点で計算
+			ratio := (float64(v) - float64(lo)) / (float64(hi) - float64(lo))
+			i = min(max(int(ratio*float64(len(sparkRunes)-1)), 0), len(sparkRunes)-1)
+		}
+		b.WriteRune(sparkRunes[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+func TestCounterHistory(t *testing.T) {
+	t.Run("元に戻す・やり直し", func(t *testing.T) {
+		m := NewCounterModel()
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeySpace})
+
+		m = sendCounterKey(m, runeKey("u"))
+		if m.count != 2 {
+			t.Errorf("リセット前に戻るべき、実際: %d", m.count)
+		}
+		m = sendCounterKey(m, runeKey("u"))
+		m = sendCounterKey(m, runeKey("u"))
+		m = sendCounterKey(m, runeKey("u"))
+		if m.count != 0 {
+			t.Errorf("最初の値より前には戻らないべき、実際: %d", m.count)
+		}
+
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+		if m.count != 1 {
+			t.Errorf("やり直しで次の値になるべき、実際: %d", m.count)
+		}
+	})
+
+	t.Run("変更するとやり直しできる値は破棄", func(t *testing.T) {
+		m := NewCounterModel()
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendCounterKey(m, runeKey("u"))
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+
+		if m.count != -1 || !slices.Equal(m.history.values, []int{0, -1}) {
+			t.Errorf("やり直しできないべき、実際: %d %v", m.count, m.history.values)
+		}
+	})
+
+	t.Run("履歴の最大数", func(t *testing.T) {
+		h := newCounterHistory(0)
+		for i := 1; i <= constants.CounterHistoryMax+10; i++ {
+			h = h.push(i)
+		}
+		if len(h.values) != constants.CounterHistoryMax || h.current() != constants.CounterHistoryMax+10 {
+			t.Errorf("古い値から破棄されるべき、実際: %d個、現在 %d", len(h.values), h.current())
+		}
+	})
+
+	t.Run("スパークライン", func(t *testing.T) {
+		if got := sparkline([]int{0, 7, 14, 7}); got != "▁▄█▄" {
+			t.Errorf("値の大きさに応じた高さになるべき、実際: %s", got)
+		}
+		if got := sparkline([]int{3, 3}); got != "▁▁" {
+			t.Errorf("同じ値は同じ高さになるべき、実際: %s", got)
+		}
+		if got := sparkline([]int{math.MinInt, 0, math.MaxInt}); got != "▁▄█" {
+			t.Errorf("差がintに収まらない値でも描画できるべき、実際: %s", got)
+		}
+		if got := sparkline([]int{0, 2000000000000000000}); got != "▁█" {
+			t.Errorf("大きな値でも描画できるべき、実際: %s", got)
+		}
+	})
+
+	t.Run("Viewに推移を表示", func(t *testing.T) {
+		m := NewCounterModel()
+		if strings.Contains(m.View(), "推移") {
+			t.Error("変更前は推移を表示しないべき")
+		}
+
+		m = sendCounterKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		if !strings.Contains(m.View(), "推移: ▁█") {
+			t.Errorf("値の推移が表示されるべき:\n%s", m.View())
+		}
+	})
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/styles"
+)
+
+// 最後のカウンターは削除できない
+var errRemoveLastCounter = errors.New("最後のカウンターは削除できません")
+
+// カウンター名の入力の種類
+type counterNaming int
+
+const (
+	counterNamingNone   counterNaming = iota
+	counterNamingAdd                  // 新しいカウンターの名前
+	counterNamingRename               // 選択中のカウンターの名前変更
+)
+
+// 一覧の名前の列幅
+const counterNameColumnWidth = 12
+
+// 名前付きカウンターの一覧モデル（変更のたびに保存する）
+type counterListModel struct {
+	counters []counterModel
+	cursor   int             // 選択中のカウンター
+	naming   counterNaming   // 名前の入力中か
+	input    textinput.Model // 名前の入力欄
+	added    int             // これまでに追加した数（デフォルト名の番号）
+	config   counterConfig   // 新しく追加するカウンターの設定
+	flags    counterFlagSet  // コマンドラインで指定したフラグ（読み込んだカウンターの設定にも適用する）
+	store    counterStore    // 保存先（nilの場合は保存しない）
+	loading  bool            // ストアから読み込み中か（読み込み前の初期データを保存しないよう操作を受け付けない）
+	err      error
+	now      func() time.Time // 現在時刻（テストで差し替え可能）
+	width    int              // 端末の幅（選択中のカウンターを大きな数字で表示する）
+	height   int              // 端末の高さ（0の場合は高さを制限しない）
+}
+
+// NewCounterListModel - カウンター1つで始まる一覧を生成
+func NewCounterListModel(cfg counterConfig) counterListModel {
+	input := textinput.New()
+	input.Prompt = "名前: "
+	input.CharLimit = constants.CounterNameMaxLength
+	input.Width = constants.CounterNameMaxLength
+
+	m := counterListModel{input: input, config: cfg, now: time.Now}
+	return m.addCounter("")
+}
+
+// NewCounterListModelWithStore - 起動時に読み込み、変更のたびに保存する一覧を生成
+// flags で指定した設定は読み込んだカウンターにも上書きする
+func NewCounterListModelWithStore(cfg counterConfig, flags counterFlagSet, store counterStore) counterListModel {
+	m := NewCounterListModel(cfg)
+	m.flags = flags
+	m.store = store
+	m.loading = store != nil
+	return m
+}
+
+// Init - 初期化時のコマンド（保存済みの一覧を読み込む）
+func (m counterListModel) Init() tea.Cmd {
+	if m.store != nil {
+		return loadCountersCmd(m.store)
+	}
+	return nil
+}
+
+// 保存済みの一覧を復元
+func (m counterListModel) handleLoaded(msg counterLoadedMsg) counterListModel {
+	m.loading = false
+	if msg.err != nil {
+		// 未保存の場合は初期状態のまま始める
+		if !errors.Is(msg.err, fs.ErrNotExist) {
+			m.err = msg.err
+		}
+		return m
+	}
+	if len(msg.snapshot.counters) == 0 {
+		return m
+	}
+	m.counters = msg.snapshot.counters
+	for i, c := range m.counters {
+		cfg, err := m.flags.apply(c.config, m.config)
+		if err != nil {
+			// 保存済みの設定と組み合わせられない場合はそのカウンターの設定のまま
+			m.err = fmt.Errorf("カウンター「%s」に指定した設定を適用できません: %w", c.name, err)
+			continue
+		}
+		c.config = cfg
+		c.count = cfg.bound(c.count)
+		c.history = newCounterHistory(c.count)
+		m.counters[i] = c
+	}
+	m.cursor = min(max(msg.snapshot.cursor, 0), len(m.counters)-1)
+	m.added = max(msg.snapshot.added, len(m.counters))
+	return m.resetDaily()
+}
+
+// 選択中のカウンター
+func (m counterListModel) current() counterModel {
+	return m.counters[m.cursor]
+}
+
+// カウンターを末尾に追加して選択（名前が空の場合は「カウンターN」）
+func (m counterListModel) addCounter(name string) counterListModel {
+	m.added++
+	if name == "" {
+		name = fmt.Sprintf("カウンター%d", m.added)
+	}
+	c := NewCounterModelWithConfig(m.config)
+	c.name = name
+	c.updated = m.now()
+	m.counters = append(m.counters, c)
+	m.cursor = len(m.counters) - 1
+	return m
+}
+
+// 選択中のカウンターを削除
+func (m counterListModel) removeCounter() counterListModel {
+	if len(m.counters) <= 1 {
+		m.err = errRemoveLastCounter
+		return m
+	}
+	m.counters = append(m.counters[:m.cursor:m.cursor], m.counters[m.cursor+1:]...)
+	m.cursor = min(m.cursor, len(m.counters)-1)
+	return m.save()
+}
+
+// 毎日リセットするカウンターのうち、前日以前に変更したものをリセット
+func (m counterListModel) resetDaily() counterListModel {
+	now := m.now()
+	today := startOfDay(now)
+	changed := false
+	for i, c := range m.counters {
+		if !c.daily || c.updated.IsZero() || !c.updated.Before(today) {
+			continue
+		}
+		c.count = c.config.initial()
+		c.history = newCounterHistory(c.count)
+		c.prefix = ""
+		c.updated = now
+		m.counters[i] = c
+		changed = true
+	}
+	if !changed {
+		return m
+	}
+	return m.save()
+}
+
+// 変更をストアに保存
+func (m counterListModel) save() counterListModel {
+	if m.store == nil || m.loading {
+		return m
+	}
+	m.err = m.store.Save(counterListSnapshot{counters: m.counters, cursor: m.cursor, added: m.added})
+	return m
+}
+
+// 名前の入力を開始
+func (m counterListModel) startNaming(naming counterNaming) (counterListModel, tea.Cmd) {
+	m.naming = naming
+	m.input.SetValue("")
+	m.input.Placeholder = fmt.Sprintf("カウンター%d", m.added+1)
+	if naming == counterNamingRename {
+		m.input.SetValue(m.current().name)
+		m.input.Placeholder = ""
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+// 名前入力中のメッセージ処理
+func (m counterListModel) updateNaming(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			name := strings.TrimSpace(m.input.Value())
+			switch m.naming {
+			case counterNamingAdd:
+				m = m.addCounter(name)
+			case counterNamingRename:
+				if name != "" {
+					m.counters[m.cursor].name = name
+				}
+			}
+			m.naming = counterNamingNone
+			m.input.Blur()
+			return m.save(), nil
+		case tea.KeyEsc:
+			m.naming = counterNamingNone
+			m.input.Blur()
+			return m, nil
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Update - メッセージ処理
+func (m counterListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// 読み込みが終わるまでは終了以外のキーを無視
+	if key, ok := msg.(tea.KeyMsg); ok && m.loading {
+		if key.Type == tea.KeyCtrlC || key.String() == "q" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	if m.naming != counterNamingNone {
+		if msg, ok := msg.(counterLoadedMsg); ok {
+			return m.handleLoaded(msg), nil
+		}
+		return m.updateNaming(msg)
+	}
+
+	switch msg := msg.(type) {
+	case counterLoadedMsg:
+		return m.handleLoaded(msg), nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		m.err = nil
+		// 起動したまま日付が変わった場合もリセットする
+		m = m.resetDaily()
+
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyTab:
+			m.cursor = (m.cursor + 1) % len(m.counters)
+			return m, nil
+		case tea.KeyShiftTab:
+			m.cursor = (m.cursor - 1 + len(m.counters)) % len(m.counters)
+			return m, nil
+		case tea.KeyRunes:
+			switch string(msg.Runes) {
+			case "k":
+				m.cursor = max(m.cursor-1, 0)
+				return m, nil
+			case "j":
+				m.cursor = min(m.cursor+1, len(m.counters)-1)
+				return m, nil
+			case "a":
+				return m.startNaming(counterNamingAdd)
+			case "e":
+				return m.startNaming(counterNamingRename)
+			case "x":
+				return m.removeCounter(), nil
+			case "c":
+				m.counters[m.cursor].color = (m.current().color + 1) % (len(styles.CounterColors) + 1)
+				return m.save(), nil
+			case "d":
+				m.counters[m.cursor].daily = !m.current().daily
+				return m.save(), nil
+			case "q":
+				return m, tea.Quit
+			}
+		}
+		return m.updateCurrent(msg)
+	}
+
+	return m, nil
+}
+
+// 選択中のカウンターにキーを渡す（値が変わったら保存）
+func (m counterListModel) updateCurrent(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	before := m.current().count
+	newModel, cmd := m.current().Update(msg)
+	c := newModel.(counterModel)
+	m.counters[m.cursor] = c
+	if c.count == before {
+		return m, cmd
+	}
+	m.counters[m.cursor].updated = m.now()
+	return m.save(), cmd
+}
+
+// View - UIの描画
+func (m counterListModel) View() string {
+	var rows []string
+	for i, c := range m.counters {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		// 全角の名前でも値の列が揃うように表示幅で埋める
+		name := c.name + strings.Repeat(" ", max(counterNameColumnWidth-lipgloss.Width(c.name), 0))
+		row := cursor + name + " " + c.countStyle().Render(strconv.Itoa(c.count))
+		if c.daily {
+			row += styles.DimmedStyle.Render("  (毎日リセット)")
+		}
+		rows = append(rows, row)
+	}
+
+	current := m.current()
+	extra := current.infoView()
+	if m.naming != counterNamingNone {
+		extra += "\n\n" + m.input.View()
+	}
+	if m.loading {
+		extra += "\n\n" + styles.DimmedStyle.Render("読み込み中...")
+	}
+	if m.err != nil {
+		extra += "\n\n" + styles.ErrorStyle.Render("❌ "+m.err.Error())
+	}
+
+	help := "j/k/Tab: 選択  a: 追加  e: 名前変更  x: 削除\n" +
+		"c: 色  d: 毎日リセット\n" +
+		"↑/+ ↓/-: 増減  数字: 回数  u/Ctrl+R: 元に戻す/やり直し  s: リセット\n"
+	if m.naming != counterNamingNone {
+		help = "Enter: 確定  Esc: キャンセル\n"
+	}
+
+	render := func(list string) string {
+		return styles.BorderStyle.Render(fmt.Sprintf(
+			"%s\n\n%s%s\n\n%s",
+			styles.TitleStyle.Render(constants.CounterTitle),
+			list,
+			extra,
+			styles.HelpStyle.Render(help+constants.QuitHelp),
+		))
+	}
+
+	// 選択中のカウンターは端末に収まれば大きな数字でも表示する
+	list := strings.Join(rows, "\n")
+	plain := render(list)
+	bigRows := 0
+	if m.height > 0 {
+		// 一覧の上に空行を挟んで追加するので、その分も除いた残り
+		bigRows = m.height - lipgloss.Height(plain) - 1
+		if bigRows < 1 {
+			return plain
+		}
+	}
+	available := m.width - styles.BorderStyle.GetHorizontalFrameSize()
+	if big, ok := styles.BigDigits(strconv.Itoa(current.count), available, bigRows); ok {
+		return render(current.countStyle().Render(big) + "\n\n" + list)
+	}
+	return plain
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 現在時刻を差し替えたテスト用のカウンター一覧
+func newCounterListTestModel(now *time.Time, store counterStore) counterListModel {
+	m := NewCounterListModelWithStore(defaultCounterConfig(), nil, store)
+	m.now = func() time.Time { return *now }
+	m.counters[0].updated = *now
+	if store != nil {
+		m = m.handleLoaded(m.Init()().(counterLoadedMsg))
+	}
+	return m
+}
+
+func sendCounterListKey(m counterListModel, key tea.KeyMsg) counterListModel {
+	newModel, _ := m.Update(key)
+	return newModel.(counterListModel)
+}
+
+// 名前を入力してカウンターを追加
+func addNamedCounter(m counterListModel, name string) counterListModel {
+	m = sendCounterListKey(m, runeKey("a"))
+	m.input.SetValue(name)
+	return sendCounterListKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestCounterList(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.Local)
+
+	t.Run("追加・名前変更・削除", func(t *testing.T) {
+		m := newCounterListTestModel(&now, nil)
+		m = addNamedCounter(m, "割り込み")
+		m = addNamedCounter(m, "")
+
+		if len(m.counters) != 3 || m.counters[1].name != "割り込み" || m.counters[2].name != "カウンター3" {
+			t.Fatalf("カウンターが追加されるべき、実際: %+v", m.counters)
+		}
+
+		m = sendCounterListKey(m, runeKey("e"))
+		m.input.SetValue("デプロイ")
+		m = sendCounterListKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.current().name != "デプロイ" {
+			t.Errorf("名前が変更されるべき、実際: %s", m.current().name)
+		}
+
+		m = sendCounterListKey(m, runeKey("x"))
+		m = sendCounterListKey(m, runeKey("x"))
+		m = sendCounterListKey(m, runeKey("x"))
+		if len(m.counters) != 1 || m.err != errRemoveLastCounter {
+			t.Errorf("最後のカウンターは削除できないべき、実際: %d個 (%v)", len(m.counters), m.err)
+		}
+	})
+
+	t.Run("選択中のカウンターだけ増減", func(t *testing.T) {
+		m := newCounterListTestModel(&now, nil)
+		m = addNamedCounter(m, "割り込み")
+		m = sendCounterListKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendCounterListKey(m, runeKey("k"))
+		m = sendCounterListKey(m, tea.KeyMsg{Type: tea.KeyDown})
+
+		if m.counters[0].count != -1 || m.counters[1].count != 1 {
+			t.Errorf("それぞれの値になるべき、実際: %d, %d", m.counters[0].count, m.counters[1].count)
+		}
+	})
+
+	t.Run("変更のたびに保存して起動時に読み込む", func(t *testing.T) {
+		store := &memoryCounterStore{}
+		m := newCounterListTestModel(&now, store)
+		m = addNamedCounter(m, "割り込み")
+		m = sendCounterListKey(m, runeKey("+"))
+		m = sendCounterListKey(m, runeKey("c"))
+		if store.saves == 0 || store.snapshot.counters[1].count != 1 || store.snapshot.counters[1].color != 1 {
+			t.Fatalf("変更が保存されるべき、実際: %+v", store.snapshot)
+		}
+
+		saves := store.saves
+		m = sendCounterListKey(m, runeKey("5"))
+		if store.saves != saves {
+			t.Error("値が変わらなければ保存しないべき")
+		}
+
+		restored := newCounterListTestModel(&now, store)
+		newModel, _ := restored.Update(restored.Init()())
+		restored = newModel.(counterListModel)
+		if len(restored.counters) != 2 || restored.current().name != "割り込み" || restored.current().count != 1 {
+			t.Errorf("保存した一覧が復元されるべき、実際: %+v", restored.counters)
+		}
+	})
+
+	t.Run("日付が変わったら毎日リセット", func(t *testing.T) {
+		store := &memoryCounterStore{}
+		current := now
+		m := newCounterListTestModel(&current, store)
+		m = addNamedCounter(m, "割り込み")
+		m = sendCounterListKey(m, runeKey("d"))
+		m = sendCounterListKey(m, runeKey("3"))
+		m = sendCounterListKey(m, runeKey("+"))
+		m = sendCounterListKey(m, runeKey("k"))
+		m = sendCounterListKey(m, runeKey("+"))
+
+		current = now.AddDate(0, 0, 1)
+		m = sendCounterListKey(m, runeKey("j"))
+		if m.counters[1].count != 0 || m.counters[0].count != 1 {
+			t.Errorf("毎日リセットするカウンターだけリセットされるべき、実際: %d, %d", m.counters[0].count, m.counters[1].count)
+		}
+		if store.snapshot.counters[1].count != 0 {
+			t.Error("リセットも保存されるべき")
+		}
+	})
+
+	t.Run("読み込み時に前日の値をリセット", func(t *testing.T) {
+		c := NewCounterModel()
+		c.name = "割り込み"
+		c.count = 7
+		c.daily = true
+		c.updated = now.AddDate(0, 0, -1)
+		store := &memoryCounterStore{snapshot: &counterListSnapshot{counters: []counterModel{c}, added: 1}}
+
+		m := newCounterListTestModel(&now, store)
+		newModel, _ := m.Update(m.Init()())
+		if got := newModel.(counterListModel).current().count; got != 0 {
+			t.Errorf("前日に変更した値はリセットされるべき、実際: %d", got)
+		}
+	})
+
+	t.Run("指定したフラグは読み込んだカウンターにも適用", func(t *testing.T) {
+		c := NewCounterModelWithConfig(counterConfig{step: 1, min: intPtr(0)})
+		c.name = "周回"
+		c.count = 7
+		store := &memoryCounterStore{snapshot: &counterListSnapshot{counters: []counterModel{c}, added: 1}}
+
+		cfg, flags, err := parseCounterConfig([]string{"--step", "5", "--max", "5"}, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewCounterListModelWithStore(cfg, flags, store)
+		newModel, _ := m.Update(m.Init()())
+		got := newModel.(counterListModel).current()
+		if got.config.step != 5 || *got.config.min != 0 || *got.config.max != 5 || got.count != 5 {
+			t.Errorf("指定した項目だけ上書きして範囲に収めるべき、実際: %+v (%d)", got.config, got.count)
+		}
+	})
+
+	t.Run("保存済みの設定と組み合わせられないフラグはエラー", func(t *testing.T) {
+		c := NewCounterModelWithConfig(counterConfig{step: 1, min: intPtr(0), max: intPtr(100)})
+		c.name = "周回"
+		store := &memoryCounterStore{snapshot: &counterListSnapshot{counters: []counterModel{c}, added: 1}}
+
+		// 保存済みの下限 0 より小さい上限
+		cfg, flags, err := parseCounterConfig([]string{"--max", "-5"}, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewCounterListModelWithStore(cfg, flags, store)
+		newModel, _ := m.Update(m.Init()())
+		m = newModel.(counterListModel)
+		if m.err == nil || *m.current().config.max != 100 || !strings.Contains(m.View(), "適用できません") {
+			t.Errorf("エラーを表示して保存済みの設定のままにするべき、実際: %v %+v", m.err, m.current().config)
+		}
+	})
+
+	t.Run("読み込みが終わるまで変更も保存もしない", func(t *testing.T) {
+		c := NewCounterModel()
+		c.name = "割り込み"
+		c.count = 7
+		store := &memoryCounterStore{snapshot: &counterListSnapshot{counters: []counterModel{c}, added: 1}}
+
+		m := NewCounterListModelWithStore(defaultCounterConfig(), nil, store)
+		m = sendCounterListKey(m, runeKey("+"))
+		m = sendCounterListKey(m, runeKey("c"))
+		if store.saves != 0 || store.snapshot.counters[0].count != 7 || !strings.Contains(m.View(), "読み込み中") {
+			t.Fatalf("読み込み前の初期データを保存しないべき、実際: %d回 %+v", store.saves, store.snapshot.counters)
+		}
+		if _, cmd := m.Update(runeKey("q")); cmd == nil {
+			t.Error("読み込み中でも終了できるべき")
+		}
+
+		newModel, _ := m.Update(m.Init()())
+		m = sendCounterListKey(newModel.(counterListModel), runeKey("+"))
+		if store.saves != 1 || store.snapshot.counters[0].count != 8 {
+			t.Errorf("読み込み後は保存した値から変更するべき、実際: %d回 %+v", store.saves, store.snapshot.counters)
+		}
+	})
+
+	t.Run("View表示確認", func(t *testing.T) {
+		m := newCounterListTestModel(&now, nil)
+		m = addNamedCounter(m, "割り込み")
+		m = sendCounterListKey(m, runeKey("d"))
+
+		view := m.View()
+		for _, want := range []string{"カウンター1", "> 割り込み", "毎日リセット"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("ビューに %q が含まれているべき:\n%s", want, view)
+			}
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 保存・復元するカウンター一覧の状態
+type counterListSnapshot struct {
+	counters []counterModel
+	cursor   int
+	added    int
+}
+
+// カウンター一覧の保存先を抽象化するインターフェース
+type counterStore interface {
+	// Load - 保存済みの一覧を読み込む（未保存の場合は fs.ErrNotExist を返す）
+	Load() (counterListSnapshot, error)
+	// Save - 一覧を保存する
+	Save(s counterListSnapshot) error
+}
+
+// JSONファイルのフォーマットバージョン
+const counterFileVersion = 1
+
+// ファイル上の範囲外の動作
+var counterOverflowKeys = map[counterOverflow]string{
+	overflowClamp: "clamp",
+	overflowWrap:  "wrap",
+}
+
+// JSONファイル上の表現
+type counterFile struct {
+	Version  int             `json:"version"`
+	Cursor   int             `json:"cursor"`
+	Added    int             `json:"added"`
+	Counters []counterRecord `json:"counters"`
+}
+
+// JSONファイル上のカウンター
+type counterRecord struct {
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Color    int    `json:"color,omitempty"`
+	Daily    bool   `json:"daily,omitempty"`
+	Updated  string `json:"updated,omitempty"` // RFC 3339
+	Step     int    `json:"step"`
+	Min      *int   `json:"min,omitempty"`
+	Max      *int   `json:"max,omitempty"`
+	Overflow string `json:"overflow,omitempty"`
+}
+
+// カウンターをJSON上の表現に変換
+func newCounterRecord(c counterModel) counterRecord {
+	r := counterRecord{
+		Name:     c.name,
+		Count:    c.count,
+		Color:    c.color,
+		Daily:    c.daily,
+		Step:     c.config.step,
+		Min:      c.config.min,
+		Max:      c.config.max,
+		Overflow: counterOverflowKeys[c.config.overflow],
+	}
+	if !c.updated.IsZero() {
+		r.Updated = c.updated.Format(time.RFC3339)
+	}
+	return r
+}
+
+// JSON上の表現からカウンターに変換（履歴は保存しないので現在の値から始める）
+func (r counterRecord) toCounter() (counterModel, error) {
+	cfg := counterConfig{
+		step:     r.Step,
+		min:      r.Min,
+		max:      r.Max,
+		overflow: lookupKey(counterOverflowKeys, r.Overflow),
+	}
+	if err := cfg.validate(); err != nil {
+		return counterModel{}, fmt.Errorf("カウンター「%s」の設定: %w", r.Name, err)
+	}
+
+	c := NewCounterModelWithConfig(cfg)
+	c.name = r.Name
+	c.count = cfg.bound(r.Count)
+	c.history = newCounterHistory(c.count)
+	c.color = r.Color
+	c.daily = r.Daily
+	if r.Updated != "" {
+		updated, err := time.Parse(time.RFC3339, r.Updated)
+		if err != nil {
+			return counterModel{}, fmt.Errorf("カウンター「%s」の更新時刻が不正です: %w", r.Name, err)
+		}
+		c.updated = updated
+	}
+	return c, nil
+}
+
+// JSONファイルに保存するストア
+type jsonCounterStore struct {
+	path string
+}
+
+// データディレクトリのカウンターファイル
+func defaultCounterStore() (jsonCounterStore, error) {
+	path, err := storage.DataPath(constants.CounterFileName)
+	if err != nil {
+		return jsonCounterStore{}, err
+	}
+	return jsonCounterStore{path: path}, nil
+}
+
+// Load - JSONファイルから読み込み
+func (s jsonCounterStore) Load() (counterListSnapshot, error) {
+	var file counterFile
+	if err := storage.LoadJSON(s.path, &file); err != nil {
+		return counterListSnapshot{}, err
+	}
+	if file.Version > counterFileVersion {
+		return counterListSnapshot{}, fmt.Errorf("未対応のファイルバージョンです: %d", file.Version)
+	}
+
+	snapshot := counterListSnapshot{cursor: file.Cursor, added: file.Added}
+	for _, r := range file.Counters {
+		c, err := r.toCounter()
+		if err != nil {
+			return counterListSnapshot{}, err
+		}
+		snapshot.counters = append(snapshot.counters, c)
+	}
+	return snapshot, nil
+}
+
+// Save - JSONファイルに保存
+func (s jsonCounterStore) Save(snapshot counterListSnapshot) error {
+	file := counterFile{
+		Version: counterFileVersion,
+		Cursor:  snapshot.cursor,
+		Added:   snapshot.added,
+	}
+	for _, c := range snapshot.counters {
+		file.Counters = append(file.Counters, newCounterRecord(c))
+	}
+	return storage.SaveJSON(s.path, file)
+}
+
+// 一覧の読み込みが完了した時のメッセージ
+type counterLoadedMsg struct {
+	snapshot counterListSnapshot
+	err      error
+}
+
+// ストアから読み込むコマンド
+func loadCountersCmd(store counterStore) tea.Cmd {
+	return func() tea.Msg {
+		snapshot, err := store.Load()
+		return counterLoadedMsg{snapshot: snapshot, err: err}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// テスト用のメモリ上のカウンターの保存先
+type memoryCounterStore struct {
+	snapshot *counterListSnapshot
+	saves    int
+}
+
+func (s *memoryCounterStore) Load() (counterListSnapshot, error) {
+	if s.snapshot == nil {
+		return counterListSnapshot{}, fs.ErrNotExist
+	}
+	return *s.snapshot, nil
+}
+
+func (s *memoryCounterStore) Save(snapshot counterListSnapshot) error {
+	snapshot.counters = append([]counterModel(nil), snapshot.counters...)
+	s.snapshot = &snapshot
+	s.saves++
+	return nil
+}
+
+func TestJSONCounterStore(t *testing.T) {
+	updated := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+
+	t.Run("保存と読み込み", func(t *testing.T) {
+		store := jsonCounterStore{path: filepath.Join(t.TempDir(), "counters.json")}
+
+		deploys := NewCounterModel()
+		deploys.name = "デプロイ"
+		deploys.count = 3
+		deploys.color = 2
+		deploys.daily = true
+		deploys.updated = updated
+
+		laps := NewCounterModelWithConfig(counterConfig{step: 5, min: intPtr(0), max: intPtr(100), overflow: overflowWrap})
+		laps.name = "周回"
+		laps.count = 95
+
+		err := store.Save(counterListSnapshot{counters: []counterModel{deploys, laps}, cursor: 1, added: 4})
+		if err != nil {
+			t.Fatalf("保存に失敗: %v", err)
+		}
+
+		snapshot, err := store.Load()
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if snapshot.cursor != 1 || snapshot.added != 4 || len(snapshot.counters) != 2 {
+			t.Fatalf("一覧の状態が復元されるべき、実際: %+v", snapshot)
+		}
+
+		got := snapshot.counters[0]
+		if got.name != "デプロイ" || got.count != 3 || got.color != 2 || !got.daily || !got.updated.Equal(updated) {
+			t.Errorf("カウンターが復元されるべき、実際: %+v", got)
+		}
+		got = snapshot.counters[1]
+		if got.count != 95 || got.config.step != 5 || *got.config.min != 0 || *got.config.max != 100 || got.config.overflow != overflowWrap {
+			t.Errorf("設定が復元されるべき、実際: %+v", got.config)
+		}
+		if got.history.current() != 95 {
+			t.Errorf("履歴は現在の値から始まるべき、実際: %v", got.history.values)
+		}
+	})
+
+	t.Run("ファイルがない場合", func(t *testing.T) {
+		store := jsonCounterStore{path: filepath.Join(t.TempDir(), "none.json")}
+
+		if _, err := store.Load(); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fs.ErrNotExist を返すべき、実際: %v", err)
+		}
+	})
+
+	t.Run("不正な設定はエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "counters.json")
+		os.WriteFile(path, []byte(`{"version":1,"counters":[{"name":"周回","count":1,"step":0}]}`), 0o644)
+
+		if _, err := (jsonCounterStore{path: path}).Load(); err == nil {
+			t.Error("エラーになるべき")
+		}
+	})
+}
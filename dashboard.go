@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,10 +35,16 @@ type dashboardModel struct {
 	height       int
 	showHelp     bool
 	globalHelp   bool
+	notice       string // タイマー終了などの通知（次のキー入力で消える）
 }
 
-// コンストラクタ
+// コンストラクタ（タイマーのベルは鳴らさない）
 func NewDashboardModel() dashboardModel {
+	return NewDashboardModelWithBell(nil)
+}
+
+// NewDashboardModelWithBell - タイマーのベルをプログラムの出力先に鳴らすダッシュボードを生成
+func NewDashboardModelWithBell(bell io.Writer) dashboardModel {
 	panels := []panel{
 		{
 			title:     "カウンター",
@@ -47,7 +54,7 @@ func NewDashboardModel() dashboardModel {
 		},
 		{
 			title:     "タイマー",
-			model:     NewTimerModel(),
+			model:     NewTimerListModel(bell),
 			panelType: panelTimer,
 			active:    false,
 		},
@@ -91,8 +98,15 @@ func (m dashboardModel) Init() tea.Cmd {
 func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// タイマーの終了はタイトルバーで知らせる（パネルへの配信は default で行う）
+	if msg, ok := msg.(timerFinishedMsg); ok {
+		m.notice = msg.String()
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.notice = ""
+
 		// グローバルキーバインディング
 		switch msg.Type {
 		case tea.KeyCtrlC:
@@ -240,7 +254,11 @@ func (m dashboardModel) View() string {
 		Width(m.width)
 
 	// タイトルバー
-	title := titleStyle.Render("🎛️  Bubble Tea ダッシュボード - 統合アプリケーション")
+	titleText := "🎛️  Bubble Tea ダッシュボード - 統合アプリケーション"
+	if m.notice != "" {
+		titleText += "  " + m.notice
+	}
+	title := titleStyle.Render(titleText)
 
 	// パネルサイズ計算
 	panelWidth := (m.width - 4) / 2 // 2列レイアウト
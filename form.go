@@ -2,110 +2,183 @@ package main
 
 import (
 	"fmt"
-	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
-
-// フォームの入力フィールド
-const (
-	nameInput = iota
-	emailInput
-	submitButton
+	"github.com/ktny/bubbletea-learning/pkg/constants"
 )
 
 // フォームの状態
 type formState int
 
 const (
-	formInput formState = iota
+	formInput       formState = iota
+	formResume                // 下書きを再開するかの確認
+	formReview                // 複数ページのフォームで送信前の確認画面
+	formSubmitting            // 送信先に送信中
+	formSubmitError           // 送信に失敗（リトライできる）
 	formSubmitted
 )
 
-// フォームモデル
+// フォームモデル（schemaのフィールドを上から順に入力し、最後に送信ボタン）
 type formModel struct {
-	inputs       []textinput.Model
-	focusIndex   int
+	schema       formSchema
+	fields       []formField // schema.fields と同じ順の入力欄
+	focusIndex   int         // len(fields) の場合は送信ボタン
 	state        formState
 	errorMessage string
 	submitted    bool
-}
+	spinner      spinner.Model // 非同期の検証中・送信中に表示するスピナー
+	pendingNext  bool          // 非同期の検証が終わったら次へ進むか
+	page         int           // 表示中のページ（schema.pages の位置）
+
+	sink       formSink         // 送信先（nilの場合は送信せずに完了する）
+	sendErr    string           // 送信のエラー
+	retryCount int              // 送信のリトライ回数
+	maxRetries int              // 送信のリトライの上限
+	now        func() time.Time // 現在時刻（テストで差し替え可能）
 
-// 入力データの構造体
-type formData struct {
-	name  string
-	email string
+	quitOnSubmit bool // 送信が完了したら終了するか（シェルスクリプトからの入力に使う）
+
+	drafts      formDraftStore // 下書きの保存先（nilの場合は保存しない）
+	draftErr    error          // 下書きの保存・読み込みのエラー
+	resumeDraft *formDraft     // 再開するかを確認中の下書き
 }
 
-// コンストラクタ
+// 入力データ（フィールドIDごとの値、型はフィールドの種類による）
+type formData map[string]any
+
+// 入力欄の幅
+const formInputWidth = 30
+
+// コンストラクタ（ユーザー登録フォーム）
 func NewFormModel() formModel {
-	// 名前入力フィールド
-	nameField := textinput.New()
-	nameField.Placeholder = "例: 山田太郎"
-	nameField.Focus()
-	nameField.CharLimit = 50
-	nameField.Width = 30
-
-	// メール入力フィールド
-	emailField := textinput.New()
-	emailField.Placeholder = "例: taro@example.com"
-	emailField.CharLimit = 100
-	emailField.Width = 30
-
-	inputs := []textinput.Model{nameField, emailField}
-
-	return formModel{
-		inputs:     inputs,
-		focusIndex: nameInput,
+	return NewFormModelWithSchema(registrationFormSchema())
+}
+
+// NewFormModelWithSchema - フォームの定義からモデルを生成
+func NewFormModelWithSchema(schema formSchema) formModel {
+	today := time.Now()
+	fields := make([]formField, len(schema.fields))
+	for i, f := range schema.fields {
+		fields[i] = newFormField(f, today)
+		if f.defaultVal != "" {
+			fields[i] = fields[i].setValue(f.defaultVal)
+		}
+		if len(f.defaultOpts) > 0 {
+			fields[i] = fields[i].selectOptions(f.defaultOpts)
+		}
+	}
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+
+	m := formModel{
+		schema:     schema,
+		fields:     fields,
 		state:      formInput,
+		spinner:    sp,
+		maxRetries: constants.MaxRetries,
+		now:        time.Now,
 	}
+	return m.goToPage(0)
+}
+
+// NewFormModelWithSink - 送信先を指定してモデルを生成
+func NewFormModelWithSink(schema formSchema, sink formSink) formModel {
+	m := NewFormModelWithSchema(schema)
+	m.sink = sink
+	return m
 }
 
 // Init - 初期化
 func (m formModel) Init() tea.Cmd {
-	// 最初のフィールドにフォーカスを当てる
+	// 最初のフィールドにフォーカスを当て、保存済みの下書きを読み込む
+	if m.drafts != nil {
+		return tea.Batch(textinput.Blink, loadFormDraftCmd(m.drafts, m.schema.id))
+	}
 	return textinput.Blink
 }
 
 // Update - メッセージ処理
 func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case formValidatedMsg:
+		return m.handleValidated(msg)
+
+	case formSentMsg:
+		return m.handleSent(msg)
+
+	case formDraftLoadedMsg:
+		return m.handleDraftLoaded(msg), nil
+
+	case spinner.TickMsg:
+		// 確認中のフィールドがある間・送信中だけスピナーを回す
+		if m.anyChecking() || m.state == formSubmitting {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		switch m.state {
+		case formSubmitting:
+			if msg.Type == tea.KeyCtrlC {
+				return m.saveDraft(), tea.Quit
+			}
+			return m, nil
+		case formResume:
+			return m.updateResume(msg)
+		case formSubmitError:
+			return m.updateSubmitError(msg)
+		case formReview:
+			return m.updateReview(msg)
+		}
+
 		switch msg.Type {
 		case tea.KeyEnter:
-			if m.focusIndex == submitButton {
-				// 送信処理
-				if err := m.validate(); err != nil {
-					m.errorMessage = err.Error()
-					return m, nil
-				}
-				m.state = formSubmitted
-				m.submitted = true
-				return m, nil
+			if m.focusIndex == m.submitIndex() {
+				return m.advance()
 			}
-			// Enterキーで次のフィールドへ
-			m = m.nextField()
-			return m, nil
+			// 複数行テキストは改行、それ以外は確定して次のフィールドへ
+			if m.fields[m.focusIndex].usesEnter() {
+				break
+			}
+			m.fields[m.focusIndex] = m.fields[m.focusIndex].confirm()
+			return m.leaveField(m.nextField)
 
 		case tea.KeyTab, tea.KeyShiftTab:
 			// Tab/Shift+Tabでフィールド移動
 			if msg.Type == tea.KeyTab {
-				m = m.nextField()
-			} else {
-				m = m.prevField()
+				return m.leaveField(m.nextField)
 			}
-			return m, nil
+			return m.leaveField(m.prevField)
 
-		case tea.KeyUp:
-			m = m.prevField()
-			return m, nil
+		case tea.KeyUp, tea.KeyDown:
+			// 選択肢・複数行テキスト・日付では入力欄の操作に使う
+			if m.focusIndex < m.submitIndex() && m.fields[m.focusIndex].usesArrows() {
+				break
+			}
+			if msg.Type == tea.KeyUp {
+				return m.leaveField(m.prevField)
+			}
+			return m.leaveField(m.nextField)
 
-		case tea.KeyDown:
-			m = m.nextField()
-			return m, nil
+		case tea.KeyPgDown, tea.KeyPgUp:
+			// 複数ページのフォームではページを移動
+			if m.wizard() && m.state == formInput {
+				if msg.Type == tea.KeyPgDown {
+					return m.advance()
+				}
+				return m.prevPage(), nil
+			}
 
 		case tea.KeyRunes:
 			switch string(msg.Runes) {
@@ -116,101 +189,252 @@ func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case tea.KeyEsc, tea.KeyCtrlC:
+			// 入力途中であれば下書きを保存してから終了
+			if m.state != formSubmitted {
+				m = m.saveDraft()
+			}
 			return m, tea.Quit
 		}
 
 		// 入力中の状態でエラーメッセージをクリア
-		if m.focusIndex < len(m.inputs) && m.errorMessage != "" {
+		if m.focusIndex < len(m.fields) && m.errorMessage != "" {
 			m.errorMessage = ""
 		}
 	}
 
-	// textinputの更新
+	// 入力欄の更新
 	cmd := m.updateInputs(msg)
 	return m, cmd
 }
 
 // 入力フィールドの更新
 func (m *formModel) updateInputs(msg tea.Msg) tea.Cmd {
-	cmds := make([]tea.Cmd, len(m.inputs))
-
 	// 現在フォーカスがあるフィールドのみ更新
-	for i := range m.inputs {
-		if i == m.focusIndex {
-			m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
-		}
+	if m.focusIndex >= len(m.fields) {
+		return nil
 	}
+	before := m.fields[m.focusIndex].value()
+	var cmd tea.Cmd
+	m.fields[m.focusIndex], cmd = m.fields[m.focusIndex].update(msg)
+	// 値を変更したらエラーを消して、確認中の結果は無視する
+	if m.fields[m.focusIndex].value() != before {
+		m.fields[m.focusIndex] = m.fields[m.focusIndex].resetCheck()
+	}
+	return cmd
+}
 
-	return tea.Batch(cmds...)
+// フォーカス中のフィールドを検証してから移動
+func (m formModel) leaveField(move func() formModel) (formModel, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.focusIndex < m.submitIndex() {
+		m, cmd = m.checkField(m.focusIndex)
+	}
+	m = move()
+	return m.saveDraft(), cmd
 }
 
-// 次のフィールドへ移動
-func (m formModel) nextField() formModel {
-	m.focusIndex++
-	if m.focusIndex > submitButton {
-		m.focusIndex = nameInput
+// フィールドを検証（同期の検証を通れば非同期の検証を開始する）
+func (m formModel) checkField(i int) (formModel, tea.Cmd) {
+	f := m.fields[i]
+	f.err = f.spec.validate(f.value())
+	value := strings.TrimSpace(f.value())
+	if f.err != nil || f.spec.async == nil || value == "" || value == f.verified {
+		m.fields[i] = f.cancelCheck()
+		return m, nil
 	}
+	if f.checking && f.checkValue == value {
+		// 同じ値を確認中
+		m.fields[i] = f
+		return m, nil
+	}
+	f.checkSeq++
+	f.checking = true
+	f.checkValue = value
+	m.fields[i] = f
+	return m, tea.Batch(m.spinner.Tick, runFormAsyncValidator(f.spec.async, i, f.checkSeq, value))
+}
 
-	// フォーカスを更新
-	for i := range m.inputs {
-		if i == m.focusIndex {
-			m.inputs[i].Focus()
-		} else {
-			m.inputs[i].Blur()
-		}
+// 非同期の検証の結果を反映（送信待ちで全ての確認が終わったら送信）
+func (m formModel) handleValidated(msg formValidatedMsg) (formModel, tea.Cmd) {
+	if msg.field < 0 || msg.field >= len(m.fields) {
+		return m, nil
+	}
+	f := m.fields[msg.field]
+	if !f.checking || msg.seq != f.checkSeq {
+		return m, nil
 	}
+	f.checking = false
+	f.err = msg.err
+	if msg.err == nil {
+		f.verified = msg.value
+	}
+	m.fields[msg.field] = f
 
-	return m
+	if !m.pendingNext || m.anyChecking() {
+		return m, nil
+	}
+	return m.advance()
 }
 
-// 前のフィールドへ移動
-func (m formModel) prevField() formModel {
-	m.focusIndex--
-	if m.focusIndex < nameInput {
-		m.focusIndex = submitButton
+// 確認中のフィールドがあるか
+func (m formModel) anyChecking() bool {
+	for _, f := range m.fields {
+		if f.checking {
+			return true
+		}
 	}
+	return false
+}
 
-	// フォーカスを更新
-	for i := range m.inputs {
-		if i == m.focusIndex {
-			m.inputs[i].Focus()
-		} else {
-			m.inputs[i].Blur()
+// 送信ボタンの処理（複数ページのフォームでは、入力中は次のページ・確認画面へ進む）
+func (m formModel) advance() (formModel, tea.Cmd) {
+	if m.wizard() && m.state == formInput {
+		return m.nextPage()
+	}
+	return m.submit()
+}
+
+// フィールドを検証（エラーがあればメッセージを設定し、確認中のものがあれば終わってから次へ進む）
+func (m formModel) checkFields(indices []int) (formModel, tea.Cmd, bool) {
+	var cmds []tea.Cmd
+	errCount := 0
+	for _, i := range indices {
+		var cmd tea.Cmd
+		m, cmd = m.checkField(i)
+		cmds = append(cmds, cmd)
+		if m.fields[i].err != nil {
+			errCount++
 		}
 	}
 
-	return m
+	m.pendingNext = false
+	switch {
+	case errCount > 0:
+		m.errorMessage = fmt.Sprintf("%d件の入力エラーがあります", errCount)
+		return m, tea.Batch(cmds...), false
+	case m.anyChecking():
+		m.pendingNext = true
+		m.errorMessage = ""
+		return m, tea.Batch(cmds...), false
+	}
+	m.errorMessage = ""
+	return m, nil, true
+}
+
+// 送信（表示するフィールドを全て検証し、確認中のものがあれば終わるまで待つ）
+func (m formModel) submit() (formModel, tea.Cmd) {
+	m, cmd, ok := m.checkFields(m.visibleFields())
+	if !ok {
+		return m, cmd
+	}
+	if m.sink == nil {
+		return m.complete()
+	}
+	m.retryCount = 0
+	return m.send()
 }
 
-// バリデーション
-func (m formModel) validate() error {
-	// 名前のチェック
-	name := strings.TrimSpace(m.inputs[nameInput].Value())
-	if name == "" {
-		return fmt.Errorf("名前を入力してください")
+// 送信先に送る
+func (m formModel) send() (formModel, tea.Cmd) {
+	m.state = formSubmitting
+	m.sendErr = ""
+	return m, tea.Batch(m.spinner.Tick, submitFormCmd(m.sink, m.submission()))
+}
+
+// 送信する入力データ
+func (m formModel) submission() formSubmission {
+	s := formSubmission{form: m.schema.id, data: m.getFormData(), at: m.now()}
+	for _, f := range m.schema.fields {
+		s.fields = append(s.fields, f.id)
 	}
+	return s
+}
 
-	// メールアドレスのチェック
-	email := strings.TrimSpace(m.inputs[emailInput].Value())
-	if email == "" {
-		return fmt.Errorf("メールアドレスを入力してください")
+// 送信結果を反映
+func (m formModel) handleSent(msg formSentMsg) (formModel, tea.Cmd) {
+	if m.state != formSubmitting {
+		return m, nil
 	}
+	if msg.err != nil {
+		m.state = formSubmitError
+		m.sendErr = msg.err.Error()
+		return m, nil
+	}
+	return m.complete()
+}
 
-	// メール形式のチェック
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(email) {
-		return fmt.Errorf("正しいメールアドレスの形式で入力してください")
+// 送信を完了する（quitOnSubmit の場合はそのまま終了）
+func (m formModel) complete() (formModel, tea.Cmd) {
+	m.state = formSubmitted
+	m.submitted = true
+	m = m.discardDraft()
+	if m.quitOnSubmit {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// 送信エラー画面のキー処理（リトライ・入力に戻る・終了）
+func (m formModel) updateSubmitError(msg tea.KeyMsg) (formModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if m.retryCount < m.maxRetries {
+			m.retryCount++
+			return m.send()
+		}
+	case tea.KeyEsc:
+		// 複数ページのフォームでは確認画面に戻る
+		m.state = formInput
+		if m.wizard() {
+			m.state = formReview
+		}
+		m.sendErr = ""
+		return m, nil
+	case tea.KeyCtrlC:
+		return m.saveDraft(), tea.Quit
 	}
+	return m, nil
+}
 
-	return nil
+// 送信ボタンの位置
+func (m formModel) submitIndex() int {
+	return len(m.fields)
+}
+
+// 指定した位置にフォーカスを移す
+func (m formModel) focus(index int) formModel {
+	m.focusIndex = index
+	for i := range m.fields {
+		if i == m.focusIndex {
+			m.fields[i], _ = m.fields[i].focus()
+		} else {
+			m.fields[i] = m.fields[i].blur()
+		}
+	}
+	return m
+}
+
+// 次のフィールドへ移動（送信ボタンの次はページの先頭）
+func (m formModel) nextField() formModel {
+	order := append(m.pageFields(m.page), m.submitIndex())
+	i := slices.Index(order, m.focusIndex)
+	return m.focus(order[(i+1)%len(order)])
+}
+
+// 前のフィールドへ移動（ページの先頭の前は送信ボタン）
+func (m formModel) prevField() formModel {
+	order := append(m.pageFields(m.page), m.submitIndex())
+	i := slices.Index(order, m.focusIndex)
+	return m.focus(order[(i+len(order)-1)%len(order)])
 }
 
 // フォームデータの取得
 func (m formModel) getFormData() formData {
-	return formData{
-		name:  strings.TrimSpace(m.inputs[nameInput].Value()),
-		email: strings.TrimSpace(m.inputs[emailInput].Value()),
+	data := make(formData, len(m.fields))
+	for _, i := range m.visibleFields() {
+		data[m.schema.fields[i].id] = m.fields[i].data()
 	}
+	return data
 }
 
 // View - UIの描画
@@ -221,14 +445,15 @@ func (m formModel) View() string {
 		Foreground(lipgloss.Color("12")).
 		MarginBottom(1)
 
+	// ラベルの列は全角文字を含む最も長いラベルに合わせる
 	labelStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("7")).
-		Width(15)
+		Width(m.labelWidth())
 
 	focusedLabelStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("14")).
 		Bold(true).
-		Width(15)
+		Width(m.labelWidth())
 
 	buttonStyle := lipgloss.NewStyle().
 		Background(lipgloss.Color("12")).
@@ -247,11 +472,27 @@ func (m formModel) View() string {
 		Foreground(lipgloss.Color("9")).
 		MarginTop(1)
 
+	fieldErrorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9"))
+
+	fieldStatusStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8"))
+
+	fieldOKStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("10"))
+
 	successStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("10")).
 		Bold(true).
 		MarginTop(1)
 
+	progressStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("14"))
+
+	pageTitleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("12")).
+		Bold(true)
+
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("8")).
 		Italic(true).
@@ -262,47 +503,114 @@ func (m formModel) View() string {
 		BorderForeground(lipgloss.Color("12")).
 		Padding(1, 2)
 
+	switch m.state {
+	case formResume:
+		d := m.resumeDraft
+		content := titleStyle.Render(m.schema.title) + "\n\n"
+		content += fmt.Sprintf("入力途中の下書きがあります（%s に保存）\n", d.saved.Local().Format("2006-01-02 15:04"))
+		content += fmt.Sprintf("%d件の入力を復元して再開しますか？\n", len(d.values)+len(d.lists))
+		content += helpStyle.Render("y/Enter: 再開  n: 下書きを破棄して始める  Esc: 終了")
+		return borderStyle.Render(content)
+
+	case formSubmitting:
+		content := titleStyle.Render(m.schema.title) + "\n\n"
+		content += m.spinner.View() + " 送信中...\n"
+		if m.retryCount > 0 {
+			content += fmt.Sprintf("リトライ %d/%d\n", m.retryCount, m.maxRetries)
+		}
+		return borderStyle.Render(content)
+
+	case formSubmitError:
+		content := titleStyle.Render(m.schema.title) + "\n\n"
+		content += errorStyle.Render("❌ 送信に失敗しました") + "\n\n"
+		content += m.sendErr + "\n"
+		if m.retryCount < m.maxRetries {
+			content += helpStyle.Render("Enter: リトライ  Esc: 入力に戻る  Ctrl+C: 終了")
+		} else {
+			content += errorStyle.Render(fmt.Sprintf("リトライ回数が上限（%d回）に達しました", m.maxRetries)) + "\n"
+			content += helpStyle.Render("Esc: 入力に戻る  Ctrl+C: 終了")
+		}
+		return borderStyle.Render(content)
+
+	case formReview:
+		content := titleStyle.Render(m.schema.title) + "\n"
+		content += progressStyle.Render(m.progressView()) + "\n\n"
+		for _, p := range m.visiblePages() {
+			content += pageTitleStyle.Render(m.schema.pages[p].title) + "\n"
+			for _, i := range m.pageFields(p) {
+				content += labelStyle.Render(m.schema.fields[i].label+":") + " " + m.fields[i].display() + "\n"
+			}
+			content += "\n"
+		}
+		content += focusedButtonStyle.Render(m.submitButton())
+		if m.errorMessage != "" {
+			content += "\n" + errorStyle.Render("❌ "+m.errorMessage)
+		}
+		content += helpStyle.Render("\nEnter: 送信  PgUp/Esc: 前のページに戻る  Ctrl+C: 終了")
+		return borderStyle.Render(content)
+	}
+
 	// 送信済み画面
 	if m.state == formSubmitted {
-		data := m.getFormData()
 		content := titleStyle.Render("📨 フォーム送信完了") + "\n\n"
 		content += successStyle.Render("✅ 正常に送信されました！") + "\n\n"
-		content += labelStyle.Render("名前:") + " " + data.name + "\n"
-		content += labelStyle.Render("メール:") + " " + data.email + "\n\n"
+		for _, i := range m.visibleFields() {
+			content += labelStyle.Render(m.fields[i].spec.label+":") + " " + m.fields[i].display() + "\n"
+		}
+		content += "\n"
 		content += helpStyle.Render("q: 終了")
 		return borderStyle.Render(content)
 	}
 
 	// フォーム入力画面
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("📝 ユーザー登録フォーム"))
-	content.WriteString("\n\n")
-
-	// 名前フィールド
-	label := "名前:"
-	if m.focusIndex == nameInput {
-		content.WriteString(focusedLabelStyle.Render(label))
-	} else {
-		content.WriteString(labelStyle.Render(label))
+	content.WriteString(titleStyle.Render(m.schema.title))
+	content.WriteString("\n")
+	if m.wizard() {
+		content.WriteString(progressStyle.Render(m.progressView()))
+		content.WriteString("\n")
 	}
 	content.WriteString("\n")
-	content.WriteString(m.inputs[nameInput].View())
-	content.WriteString("\n\n")
 
-	// メールフィールド
-	label = "メールアドレス:"
-	if m.focusIndex == emailInput {
-		content.WriteString(focusedLabelStyle.Render(label))
-	} else {
-		content.WriteString(labelStyle.Render(label))
+	for _, i := range m.pageFields(m.page) {
+		f := m.schema.fields[i]
+		label := f.label + ":"
+		if m.focusIndex == i {
+			content.WriteString(focusedLabelStyle.Render(label))
+			// 入力中の長さ（全角文字は表示幅の単位では2と数える）
+			if length := m.fields[i].lengthView(); length != "" {
+				content.WriteString(fieldStatusStyle.Render(length))
+			}
+		} else {
+			content.WriteString(labelStyle.Render(label))
+		}
+		content.WriteString("\n")
+		content.WriteString(m.fields[i].view())
+		content.WriteString("\n")
+		// 検証の状態（確認中・エラー・確認済み）
+		switch f := m.fields[i]; {
+		case f.checking:
+			content.WriteString(m.spinner.View() + " " + fieldStatusStyle.Render("確認中..."))
+			content.WriteString("\n")
+		case f.err != nil:
+			content.WriteString(fieldErrorStyle.Render("❌ " + f.err.Error()))
+			content.WriteString("\n")
+		case f.spec.async != nil && f.verified != "" && f.verified == strings.TrimSpace(f.value()):
+			content.WriteString(fieldOKStyle.Render("✓ 確認済み"))
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
 	}
-	content.WriteString("\n")
-	content.WriteString(m.inputs[emailInput].View())
-	content.WriteString("\n\n")
 
-	// 送信ボタン
-	button := "[ 送信 ]"
-	if m.focusIndex == submitButton {
+	// 送信ボタン（複数ページのフォームでは次へ・確認へ）
+	button := m.submitButton()
+	if m.wizard() {
+		button = "[ 確認へ ]"
+		if _, ok := m.followingPage(); ok {
+			button = "[ 次へ ]"
+		}
+	}
+	if m.focusIndex == m.submitIndex() {
 		content.WriteString(focusedButtonStyle.Render(button))
 	} else {
 		content.WriteString(buttonStyle.Render(button))
@@ -313,10 +621,30 @@ func (m formModel) View() string {
 		content.WriteString("\n")
 		content.WriteString(errorStyle.Render("❌ " + m.errorMessage))
 	}
+	if m.draftErr != nil {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render("❌ 下書きを保存できません: " + m.draftErr.Error()))
+	}
 
 	// ヘルプテキスト
 	help := "\nTab: 次へ  Shift+Tab: 前へ  Enter: 決定  Esc: 終了"
+	if m.wizard() {
+		help += "\nPgDn: 次のページ  PgUp: 前のページ"
+	}
+	if m.focusIndex < len(m.fields) {
+		if h := m.fields[m.focusIndex].help(); h != "" {
+			help = "\n" + h + help
+		}
+	}
 	content.WriteString(helpStyle.Render(help))
 
 	return borderStyle.Render(content.String())
 }
+
+// 送信ボタンの表示
+func (m formModel) submitButton() string {
+	if m.schema.submitLabel != "" {
+		return "[ " + m.schema.submitLabel + " ]"
+	}
+	return "[ 送信 ]"
+}
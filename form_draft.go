@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 入力途中のフォームの下書き。フィールドから離れた時・ページの移動・終了時に保存し、
+// 次に同じフォームを開いた時に再開するかを確認する。送信が完了したら削除する。
+// パスワードはファイルに残さないように下書きに含めない。
+
+// 下書き
+type formDraft struct {
+	values map[string]string   // フィールドIDごとの値（formField.value の表現）
+	lists  map[string][]string // 複数選択のフィールドIDごとの選択肢（選択肢に「,」を含んでも崩れないように分けて持つ）
+	focus  string              // フォーカスしていたフィールドのID（空の場合は送信ボタン）
+	page   int                 // 表示していたページ
+	saved  time.Time           // 保存した時刻
+}
+
+// 下書きに入力があるか
+func (d formDraft) empty() bool {
+	return len(d.values) == 0 && len(d.lists) == 0
+}
+
+// 下書きの保存先を抽象化するインターフェース
+type formDraftStore interface {
+	// Load - フォームの下書きを読み込む（下書きがない場合は fs.ErrNotExist を返す）
+	Load(form string) (formDraft, error)
+	// Save - フォームの下書きを保存する
+	Save(form string, d formDraft) error
+	// Delete - フォームの下書きを削除する
+	Delete(form string) error
+}
+
+// JSONファイルのフォーマットバージョン
+const formDraftFileVersion = 1
+
+// JSONファイル上の表現（フォームIDごとの下書き）
+type formDraftFile struct {
+	Version int                        `json:"version"`
+	Drafts  map[string]formDraftRecord `json:"drafts"`
+}
+
+// JSONファイル上の下書き
+type formDraftRecord struct {
+	Values  map[string]string   `json:"values"`
+	Lists   map[string][]string `json:"lists,omitempty"`
+	Focus   string              `json:"focus,omitempty"`
+	Page    int                 `json:"page,omitempty"`
+	SavedAt string              `json:"saved_at"` // RFC 3339
+}
+
+// JSONファイルに保存するストア
+type jsonFormDraftStore struct {
+	path string
+}
+
+// データディレクトリの下書きファイル
+func defaultFormDraftStore() (jsonFormDraftStore, error) {
+	path, err := storage.DataPath(constants.FormDraftFileName)
+	if err != nil {
+		return jsonFormDraftStore{}, err
+	}
+	return jsonFormDraftStore{path: path}, nil
+}
+
+// ファイル全体を読み込む（ファイルがない場合は空）
+func (s jsonFormDraftStore) load() (formDraftFile, error) {
+	file := formDraftFile{Version: formDraftFileVersion}
+	if err := storage.LoadJSON(s.path, &file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return formDraftFile{}, err
+	}
+	if file.Version > formDraftFileVersion {
+		return formDraftFile{}, fmt.Errorf("未対応のファイルバージョンです: %d", file.Version)
+	}
+	if file.Drafts == nil {
+		file.Drafts = map[string]formDraftRecord{}
+	}
+	return file, nil
+}
+
+// Load - JSONファイルから読み込み
+func (s jsonFormDraftStore) Load(form string) (formDraft, error) {
+	file, err := s.load()
+	if err != nil {
+		return formDraft{}, err
+	}
+	r, ok := file.Drafts[form]
+	if !ok {
+		return formDraft{}, fmt.Errorf("フォーム「%s」の下書き: %w", form, fs.ErrNotExist)
+	}
+	saved, err := time.Parse(time.RFC3339, r.SavedAt)
+	if err != nil {
+		return formDraft{}, fmt.Errorf("フォーム「%s」の下書きの保存時刻が不正です: %w", form, err)
+	}
+	return formDraft{values: r.Values, lists: r.Lists, focus: r.Focus, page: r.Page, saved: saved}, nil
+}
+
+// Save - JSONファイルに保存（他のフォームの下書きはそのまま残す）
+func (s jsonFormDraftStore) Save(form string, d formDraft) error {
+	file, err := s.load()
+	if err != nil {
+		return err
+	}
+	file.Version = formDraftFileVersion
+	file.Drafts[form] = formDraftRecord{
+		Values:  d.values,
+		Lists:   d.lists,
+		Focus:   d.focus,
+		Page:    d.page,
+		SavedAt: d.saved.Format(time.RFC3339),
+	}
+	return storage.SaveJSON(s.path, file)
+}
+
+// Delete - JSONファイルから削除
+func (s jsonFormDraftStore) Delete(form string) error {
+	file, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Drafts[form]; !ok {
+		return nil
+	}
+	delete(file.Drafts, form)
+	return storage.SaveJSON(s.path, file)
+}
+
+// 下書きの読み込みが完了した時のメッセージ
+type formDraftLoadedMsg struct {
+	draft formDraft
+	err   error
+}
+
+// ストアから下書きを読み込むコマンド
+func loadFormDraftCmd(store formDraftStore, form string) tea.Cmd {
+	return func() tea.Msg {
+		draft, err := store.Load(form)
+		return formDraftLoadedMsg{draft: draft, err: err}
+	}
+}
+
+// 現在の入力内容の下書き（何も入力していない場合は false）
+func (m formModel) draft() (formDraft, bool) {
+	d := formDraft{values: map[string]string{}, page: m.page, saved: m.now()}
+	if m.focusIndex < m.submitIndex() {
+		d.focus = m.schema.fields[m.focusIndex].id
+	}
+	for _, f := range m.fields {
+		if f.spec.kind == fieldMultiSelect {
+			if options := f.selectedOptions(); len(options) > 0 {
+				if d.lists == nil {
+					d.lists = map[string][]string{}
+				}
+				d.lists[f.spec.id] = options
+			}
+			continue
+		}
+		value := f.value()
+		if f.spec.kind == fieldPassword || strings.TrimSpace(value) == "" {
+			continue
+		}
+		d.values[f.spec.id] = value
+	}
+	return d, !d.empty()
+}
+
+// 下書きを保存（何も入力していない場合は削除）
+func (m formModel) saveDraft() formModel {
+	if m.drafts == nil {
+		return m
+	}
+	if d, ok := m.draft(); ok {
+		m.draftErr = m.drafts.Save(m.schema.id, d)
+	} else {
+		m.draftErr = m.drafts.Delete(m.schema.id)
+	}
+	return m
+}
+
+// 下書きを削除（送信の完了時・再開しない場合）
+func (m formModel) discardDraft() formModel {
+	if m.drafts != nil {
+		m.draftErr = m.drafts.Delete(m.schema.id)
+	}
+	return m
+}
+
+// 下書きを読み込んだら再開するかを確認する
+func (m formModel) handleDraftLoaded(msg formDraftLoadedMsg) formModel {
+	if msg.err != nil {
+		// 下書きがない場合はそのまま始める
+		if !errors.Is(msg.err, fs.ErrNotExist) {
+			m.draftErr = msg.err
+		}
+		return m
+	}
+	if m.state != formInput || msg.draft.empty() {
+		return m
+	}
+	m.resumeDraft = &msg.draft
+	m.state = formResume
+	return m
+}
+
+// 下書きの値・ページ・フォーカスを復元
+func (m formModel) restoreDraft(d formDraft) formModel {
+	for i, f := range m.fields {
+		// 以前の下書きは複数選択も values に「, 」区切りで持っている
+		if options, ok := d.lists[f.spec.id]; ok && f.spec.kind == fieldMultiSelect {
+			m.fields[i] = f.selectOptions(options)
+		} else if value, ok := d.values[f.spec.id]; ok {
+			m.fields[i] = f.setValue(value)
+		}
+	}
+
+	m = m.goToPage(0)
+	if m.wizard() && d.page > 0 && d.page < len(m.schema.pages) && m.pageVisible(d.page) {
+		m = m.goToPage(d.page)
+	}
+	if d.focus == "" {
+		return m.focus(m.submitIndex())
+	}
+	if i := m.fieldIndex(d.focus); i >= 0 && slices.Contains(m.pageFields(m.page), i) {
+		return m.focus(i)
+	}
+	return m
+}
+
+// 再開の確認のキー処理（y: 再開、n: 下書きを捨てて始める）
+func (m formModel) updateResume(msg tea.KeyMsg) (formModel, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.state = formInput
+		m = m.restoreDraft(*m.resumeDraft)
+		m.resumeDraft = nil
+	case "n":
+		m.state = formInput
+		m.resumeDraft = nil
+		m = m.discardDraft()
+	case "esc", "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
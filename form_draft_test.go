@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// メモリ上の下書きの保存先
+type memoryFormDraftStore struct {
+	drafts map[string]formDraft
+}
+
+func newMemoryFormDraftStore() *memoryFormDraftStore {
+	return &memoryFormDraftStore{drafts: map[string]formDraft{}}
+}
+
+func (s *memoryFormDraftStore) Load(form string) (formDraft, error) {
+	d, ok := s.drafts[form]
+	if !ok {
+		return formDraft{}, fs.ErrNotExist
+	}
+	return d, nil
+}
+
+func (s *memoryFormDraftStore) Save(form string, d formDraft) error {
+	s.drafts[form] = d
+	return nil
+}
+
+func (s *memoryFormDraftStore) Delete(form string) error {
+	delete(s.drafts, form)
+	return nil
+}
+
+var formDraftTestTime = time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+// 下書きの保存先を指定したフォーム
+func newFormWithDrafts(schema formSchema, store formDraftStore) formModel {
+	m := NewFormModelWithSchema(schema)
+	m.drafts = store
+	m.now = func() time.Time { return formDraftTestTime }
+	return m
+}
+
+// 起動時の下書きの読み込みを実行
+func startFormWithDrafts(t *testing.T, m formModel) formModel {
+	t.Helper()
+	for _, c := range m.Init()().(tea.BatchMsg) {
+		if msg, ok := c().(formDraftLoadedMsg); ok {
+			newModel, _ := m.Update(msg)
+			return newModel.(formModel)
+		}
+	}
+	t.Fatal("下書きを読み込むべき")
+	return m
+}
+
+func TestJSONFormDraftStore(t *testing.T) {
+	store := jsonFormDraftStore{path: filepath.Join(t.TempDir(), "form_drafts.json")}
+	if _, err := store.Load("registration"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("下書きがなければ fs.ErrNotExist になるべき、実際: %v", err)
+	}
+
+	store.Save("registration", formDraft{values: map[string]string{"name": "山田"}, focus: "email", saved: formDraftTestTime})
+	store.Save("survey", formDraft{
+		values: map[string]string{"team": "開発"},
+		lists:  map[string][]string{"cities": {"Tokyo, Japan"}},
+		page:   2,
+		saved:  formDraftTestTime,
+	})
+
+	d, err := store.Load("survey")
+	if err != nil || d.values["team"] != "開発" || !slices.Equal(d.lists["cities"], []string{"Tokyo, Japan"}) || d.page != 2 || !d.saved.Equal(formDraftTestTime) {
+		t.Errorf("フォームごとに読み込めるべき、実際: %+v (%v)", d, err)
+	}
+
+	store.Delete("survey")
+	if _, err := store.Load("survey"); !errors.Is(err, fs.ErrNotExist) {
+		t.Error("削除した下書きは読み込めないべき")
+	}
+	if d, _ := store.Load("registration"); d.values["name"] != "山田" || d.focus != "email" {
+		t.Errorf("他のフォームの下書きは残るべき、実際: %+v", d)
+	}
+}
+
+func TestFormDraftAutosave(t *testing.T) {
+	t.Run("Escで終了すると下書きを保存", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		m := typeFormText(newFormWithDrafts(registrationFormSchema(), store), "山田太郎")
+		m = sendFormKeys(m, formKeyTab)
+		m = typeFormText(m, "taro@")
+
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if _, ok := cmd().(tea.QuitMsg); !ok || newModel.(formModel).draftErr != nil {
+			t.Fatal("終了するべき")
+		}
+		d := store.drafts["registration"]
+		if d.values["name"] != "山田太郎" || d.values["email"] != "taro@" || d.focus != "email" {
+			t.Errorf("入力内容とフォーカスが保存されるべき、実際: %+v", d)
+		}
+	})
+
+	t.Run("フィールドを離れるたびに保存", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		m := typeFormText(newFormWithDrafts(registrationFormSchema(), store), "山田")
+		sendFormKeys(m, formKeyTab)
+		if store.drafts["registration"].values["name"] != "山田" {
+			t.Error("フィールドを離れたら保存されるべき")
+		}
+	})
+
+	t.Run("パスワードは保存しない", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		schema := formSchema{id: "login", fields: []formFieldSpec{
+			{id: "user", kind: fieldText, label: "ユーザー"},
+			{id: "password", kind: fieldPassword, label: "パスワード"},
+		}}
+		m := typeFormText(newFormWithDrafts(schema, store), "taro")
+		m = typeFormText(sendFormKeys(m, formKeyTab), "secret")
+		sendFormKeys(m, formKeyTab)
+
+		if d := store.drafts["login"]; d.values["user"] != "taro" || d.values["password"] != "" {
+			t.Errorf("パスワード以外が保存されるべき、実際: %+v", d.values)
+		}
+	})
+
+	t.Run("複数選択は選択肢ごとに保存して復元", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		schema := formSchema{id: "trip", fields: []formFieldSpec{
+			{id: "cities", kind: fieldMultiSelect, label: "行き先", options: []string{"Tokyo, Japan", "Paris, France", "Osaka"}},
+			{id: "memo", kind: fieldText, label: "メモ"},
+		}}
+		m := newFormWithDrafts(schema, store)
+		m.fields[0] = m.fields[0].selectOptions([]string{"Tokyo, Japan", "Osaka"})
+		sendFormKeys(m, formKeyTab)
+
+		d := store.drafts["trip"]
+		if !slices.Equal(d.lists["cities"], []string{"Tokyo, Japan", "Osaka"}) {
+			t.Fatalf("選択肢をそのまま保存するべき、実際: %+v", d)
+		}
+
+		m = startFormWithDrafts(t, newFormWithDrafts(schema, store))
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		if got := m.fields[0].selectedOptions(); !slices.Equal(got, []string{"Tokyo, Japan", "Osaka"}) {
+			t.Errorf("「,」を含む選択肢も復元するべき、実際: %q", got)
+		}
+	})
+
+	t.Run("送信したら下書きを削除", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		m := newFormWithDrafts(registrationFormSchema(), store)
+		m.fields[regNameField].input.SetValue("山田太郎")
+		m.fields[regEmailField].input.SetValue("taro@example.com")
+		m = sendFormKeys(m, formKeyTab, formKeyTab, formKeyEnter)
+
+		if !m.submitted || len(store.drafts) != 0 {
+			t.Errorf("送信後は下書きが残らないべき、実際: %+v", store.drafts)
+		}
+	})
+}
+
+func TestFormDraftResume(t *testing.T) {
+	draft := formDraft{
+		values: map[string]string{"name": "山田太郎", "role": "営業", "region": "西日本"},
+		focus:  "region",
+		page:   2,
+		saved:  formDraftTestTime,
+	}
+
+	t.Run("再開すると値・ページ・フォーカスを復元", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		store.drafts["onboarding"] = draft
+		m := startFormWithDrafts(t, newFormWithDrafts(onboardingFormSchema(), store))
+		if m.state != formResume || !strings.Contains(m.View(), "3件の入力を復元") {
+			t.Fatalf("再開するかを確認するべき:\n%s", m.View())
+		}
+
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+		if m.state != formInput || m.page != 2 || m.focusIndex != wizRegionField {
+			t.Errorf("ページとフォーカスを復元するべき、実際: %d %d", m.page, m.focusIndex)
+		}
+		if m.fields[wizNameField].value() != "山田太郎" || m.fields[wizRegionField].value() != "西日本" {
+			t.Error("入力した値を復元するべき")
+		}
+	})
+
+	t.Run("再開しない場合は下書きを破棄", func(t *testing.T) {
+		store := newMemoryFormDraftStore()
+		store.drafts["onboarding"] = draft
+		m := startFormWithDrafts(t, newFormWithDrafts(onboardingFormSchema(), store))
+
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		if m.state != formInput || m.page != 0 || m.fields[wizNameField].value() != "" || len(store.drafts) != 0 {
+			t.Errorf("空のフォームで始めて下書きを削除するべき、実際: %d %+v", m.page, store.drafts)
+		}
+	})
+
+	t.Run("下書きがなければそのまま始める", func(t *testing.T) {
+		m := startFormWithDrafts(t, newFormWithDrafts(registrationFormSchema(), newMemoryFormDraftStore()))
+		if m.state != formInput || m.draftErr != nil {
+			t.Errorf("入力画面で始めるべき、実際: %d (%v)", m.state, m.draftErr)
+		}
+	})
+}
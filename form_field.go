@@ -0,0 +1,413 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/styles"
+)
+
+// 日付の入力・表示形式
+const formDateLayout = "2006-01-02"
+
+// 日付の選択中の部分（←/→ で切り替え、↑/↓ で増減）
+type dateSegment int
+
+const (
+	segmentYear dateSegment = iota
+	segmentMonth
+	segmentDay
+)
+
+// 選択肢のカーソル・日付の選択中の部分のスタイル
+var formCursorStyle = lipgloss.NewStyle().
+	Foreground(styles.SecondaryColor).
+	Bold(true)
+
+// フォームの入力欄（種類に応じて使う状態が異なる）
+type formField struct {
+	spec     formFieldSpec
+	focused  bool
+	input    textinput.Model // テキスト・メール・パスワード・数値
+	area     textarea.Model  // 複数行テキスト
+	cursor   int             // 選択肢のカーソル
+	chosen   int             // 単一選択で選んだ選択肢（-1の場合は未選択）
+	selected []bool          // 複数選択で選んだ選択肢
+	checked  bool            // チェックボックス
+	date     time.Time       // 日付（ゼロ値の場合は未選択）
+	segment  dateSegment     // 日付の選択中の部分（初期状態は日）
+	today    time.Time       // 日付が未選択のまま操作した時の初期値
+
+	err        error  // 検証のエラー（フォーカスが外れた時に更新）
+	checking   bool   // 非同期の検証中か
+	checkSeq   int    // 非同期の検証の通し番号
+	checkValue string // 非同期の検証中の値
+	verified   string // 非同期の検証を通った値
+}
+
+// 定義から入力欄を生成
+func newFormField(spec formFieldSpec, today time.Time) formField {
+	f := formField{spec: spec, chosen: -1, segment: segmentDay, today: startOfDay(today)}
+	switch spec.kind {
+	case fieldTextarea:
+		f.area = textarea.New()
+		f.area.Placeholder = spec.placeholder
+		f.area.ShowLineNumbers = false
+		// 長さは spec.lengthUnit で数えて制限する（CharLimit はルーン数で数えるので使わない）
+		f.area.CharLimit = 0
+		f.area.SetWidth(formInputWidth)
+		f.area.SetHeight(constants.FormTextareaHeight)
+	case fieldMultiSelect:
+		f.selected = make([]bool, len(spec.options))
+	default:
+		f.input = textinput.New()
+		f.input.Placeholder = spec.placeholder
+		f.input.Width = formInputWidth
+		if spec.kind == fieldPassword {
+			f.input.EchoMode = textinput.EchoPassword
+			f.input.EchoCharacter = '•'
+		}
+	}
+	return f
+}
+
+// 値の変更で検証の結果を破棄
+func (f formField) resetCheck() formField {
+	f.err = nil
+	return f.cancelCheck()
+}
+
+// 非同期の検証を中止（結果が届いても無視する）
+func (f formField) cancelCheck() formField {
+	if f.checking {
+		f.checking = false
+		f.checkSeq++
+	}
+	return f
+}
+
+// フォーカスを当てる
+func (f formField) focus() (formField, tea.Cmd) {
+	f.focused = true
+	switch f.spec.kind {
+	case fieldTextarea:
+		return f, f.area.Focus()
+	case fieldText, fieldEmail, fieldPassword, fieldNumber:
+		return f, f.input.Focus()
+	}
+	return f, nil
+}
+
+// フォーカスを外す
+func (f formField) blur() formField {
+	f.focused = false
+	f.area.Blur()
+	f.input.Blur()
+	return f
+}
+
+// ↑/↓ を入力欄で使うか（使わない場合はフィールドの移動）
+func (f formField) usesArrows() bool {
+	switch f.spec.kind {
+	case fieldSelect, fieldMultiSelect, fieldTextarea, fieldDate:
+		return true
+	}
+	return false
+}
+
+// Enter を入力欄で使うか（使わない場合は次のフィールドへ移動）
+func (f formField) usesEnter() bool {
+	return f.spec.kind == fieldTextarea
+}
+
+// Enterで次へ進む前の確定（単一選択はカーソル位置を選ぶ）
+func (f formField) confirm() formField {
+	if f.spec.kind == fieldSelect && len(f.spec.options) > 0 {
+		f.chosen = f.cursor
+	}
+	return f
+}
+
+// 入力欄のメッセージ処理
+func (f formField) update(msg tea.Msg) (formField, tea.Cmd) {
+	var cmd tea.Cmd
+	before, pos := f.value(), f.input.Position()
+	msg = f.fitInput(msg)
+	switch f.spec.kind {
+	case fieldTextarea:
+		f.area, cmd = f.area.Update(msg)
+		return f.revertOverflow(before, pos), cmd
+	case fieldSelect, fieldMultiSelect:
+		return f.updateChoice(msg), nil
+	case fieldCheckbox:
+		if msg, ok := msg.(tea.KeyMsg); ok && (msg.Type == tea.KeySpace || msg.String() == "x") {
+			f.checked = !f.checked
+		}
+		return f, nil
+	case fieldDate:
+		return f.updateDate(msg), nil
+	case fieldNumber:
+		// 数値に使う文字以外は入力しない
+		if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyRunes &&
+			strings.Trim(string(msg.Runes), "0123456789.-") != "" {
+			return f, nil
+		}
+	}
+	f.input, cmd = f.input.Update(msg)
+	return f.revertOverflow(before, pos), cmd
+}
+
+// 選択肢の操作（↑/↓ で移動、Space で選択）
+func (f formField) updateChoice(msg tea.Msg) formField {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || len(f.spec.options) == 0 {
+		return f
+	}
+	switch key.String() {
+	case "up", "k":
+		f.cursor = max(f.cursor-1, 0)
+	case "down", "j":
+		f.cursor = min(f.cursor+1, len(f.spec.options)-1)
+	case " ":
+		if f.spec.kind == fieldSelect {
+			f.chosen = f.cursor
+		} else {
+			f.selected = append([]bool(nil), f.selected...)
+			f.selected[f.cursor] = !f.selected[f.cursor]
+		}
+	}
+	return f
+}
+
+// 日付の操作（←/→ で年・月・日を選び、↑/↓ で増減、Backspace で未選択に戻す）
+func (f formField) updateDate(msg tea.Msg) formField {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return f
+	}
+	delta := 0
+	switch key.String() {
+	case "left", "h":
+		f.segment = max(f.segment-1, segmentYear)
+	case "right", "l":
+		f.segment = min(f.segment+1, segmentDay)
+	case "up", "k", "+":
+		delta = 1
+	case "down", "j", "-":
+		delta = -1
+	case "backspace", "delete":
+		f.date = time.Time{}
+	}
+	if delta == 0 {
+		return f
+	}
+	if f.date.IsZero() {
+		// 未選択の場合は今日から始める
+		f.date = f.today
+		return f
+	}
+	switch f.segment {
+	case segmentYear:
+		f.date = f.date.AddDate(delta, 0, 0)
+	case segmentMonth:
+		f.date = f.date.AddDate(0, delta, 0)
+	case segmentDay:
+		f.date = f.date.AddDate(0, 0, delta)
+	}
+	return f
+}
+
+// 検証に使う文字列の値（未入力・未選択の場合は空）
+func (f formField) value() string {
+	switch f.spec.kind {
+	case fieldTextarea:
+		return f.area.Value()
+	case fieldSelect:
+		if f.chosen < 0 || f.chosen >= len(f.spec.options) {
+			return ""
+		}
+		return f.spec.options[f.chosen]
+	case fieldMultiSelect:
+		return strings.Join(f.selectedOptions(), ", ")
+	case fieldCheckbox:
+		if f.checked {
+			return "true"
+		}
+		return ""
+	case fieldDate:
+		if f.date.IsZero() {
+			return ""
+		}
+		return f.date.Format(formDateLayout)
+	}
+	return f.input.Value()
+}
+
+// 複数選択で選んだ選択肢
+func (f formField) selectedOptions() []string {
+	options := []string{}
+	for i, ok := range f.selected {
+		if ok {
+			options = append(options, f.spec.options[i])
+		}
+	}
+	return options
+}
+
+// 文字列から値を設定（既定値などに使う。解釈できない値は無視する）
+func (f formField) setValue(s string) formField {
+	switch f.spec.kind {
+	case fieldTextarea:
+		f.area.SetValue(s)
+	case fieldSelect:
+		for i, o := range f.spec.options {
+			if o == s {
+				f.chosen, f.cursor = i, i
+			}
+		}
+	case fieldMultiSelect:
+		values := strings.Split(s, ",")
+		for i, v := range values {
+			values[i] = strings.TrimSpace(v)
+		}
+		f = f.selectOptions(values)
+	case fieldCheckbox:
+		f.checked, _ = strconv.ParseBool(s)
+	case fieldDate:
+		if d, err := time.ParseInLocation(formDateLayout, s, time.Local); err == nil || s == "" {
+			f.date = d
+		}
+	default:
+		f.input.SetValue(s)
+	}
+	return f
+}
+
+// 複数選択で values の選択肢だけを選ぶ
+func (f formField) selectOptions(values []string) formField {
+	f.selected = make([]bool, len(f.spec.options))
+	for i, o := range f.spec.options {
+		f.selected[i] = slices.Contains(values, o)
+	}
+	return f
+}
+
+// 入力データとしての値（種類に応じた型）
+func (f formField) data() any {
+	switch f.spec.kind {
+	case fieldMultiSelect:
+		return f.selectedOptions()
+	case fieldCheckbox:
+		return f.checked
+	case fieldNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(f.value()), 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	}
+	return strings.TrimSpace(f.value())
+}
+
+// 送信後の表示（パスワードは伏せる）
+func (f formField) display() string {
+	switch f.spec.kind {
+	case fieldPassword:
+		return strings.Repeat("•", lengthGraphemes.measure(f.value()))
+	case fieldCheckbox:
+		if f.checked {
+			return "はい"
+		}
+		return "いいえ"
+	}
+	return strings.TrimSpace(f.value())
+}
+
+// 入力欄の描画
+func (f formField) view() string {
+	switch f.spec.kind {
+	case fieldTextarea:
+		return f.area.View()
+	case fieldSelect, fieldMultiSelect:
+		return f.choiceView()
+	case fieldCheckbox:
+		box := "[ ] "
+		if f.checked {
+			box = "[x] "
+		}
+		if f.focused {
+			box = formCursorStyle.Render(box)
+		}
+		return box + f.spec.placeholder
+	case fieldDate:
+		return f.dateView()
+	}
+	return f.input.View()
+}
+
+// 選択肢の描画
+func (f formField) choiceView() string {
+	var lines []string
+	for i, o := range f.spec.options {
+		mark := "( ) "
+		switch {
+		case f.spec.kind == fieldSelect && i == f.chosen:
+			mark = "(•) "
+		case f.spec.kind == fieldMultiSelect && f.selected[i]:
+			mark = "[x] "
+		case f.spec.kind == fieldMultiSelect:
+			mark = "[ ] "
+		}
+		line := "  " + mark + o
+		if f.focused && i == f.cursor {
+			line = formCursorStyle.Render("> " + mark + o)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 日付の描画（フォーカス中は選択中の部分を強調）
+func (f formField) dateView() string {
+	if f.date.IsZero() {
+		text := "----/--/--"
+		if f.spec.placeholder != "" {
+			text = f.spec.placeholder
+		}
+		return styles.DimmedStyle.Render(text)
+	}
+	parts := []string{
+		fmt.Sprintf("%04d", f.date.Year()),
+		fmt.Sprintf("%02d", int(f.date.Month())),
+		fmt.Sprintf("%02d", f.date.Day()),
+	}
+	if f.focused {
+		parts[f.segment] = formCursorStyle.Underline(true).Render(parts[f.segment])
+	}
+	return strings.Join(parts, "-")
+}
+
+// フォーカス中の入力欄の操作説明（空の場合は共通の説明のみ）
+func (f formField) help() string {
+	switch f.spec.kind {
+	case fieldSelect:
+		return "↑/↓: 移動  Space/Enter: 選択"
+	case fieldMultiSelect:
+		return "↑/↓: 移動  Space: 選択の切り替え"
+	case fieldCheckbox:
+		return "Space: チェックの切り替え"
+	case fieldTextarea:
+		return "Enter: 改行"
+	case fieldDate:
+		return "←/→: 年・月・日  ↑/↓: 増減  Backspace: クリア"
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// 全ての種類のフィールドを持つテスト用のフォーム
+func newAllKindsForm() formModel {
+	m := NewFormModelWithSchema(formSchema{
+		id:    "profile",
+		title: "プロフィール",
+		fields: []formFieldSpec{
+			{id: "plan", kind: fieldSelect, label: "プラン", required: true, options: []string{"無料", "有料"}},
+			{id: "langs", kind: fieldMultiSelect, label: "言語", options: []string{"Go", "Rust", "Python"}},
+			{id: "agree", kind: fieldCheckbox, label: "規約", placeholder: "規約に同意する", required: true},
+			{id: "bio", kind: fieldTextarea, label: "自己紹介"},
+			{id: "age", kind: fieldNumber, label: "年齢", min: floatPtr(0), max: floatPtr(150)},
+			{id: "birthday", kind: fieldDate, label: "誕生日"},
+			{id: "password", kind: fieldPassword, label: "パスワード", required: true},
+		},
+	})
+	for i := range m.fields {
+		m.fields[i].today = time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)
+	}
+	return m
+}
+
+func sendFormKeys(m formModel, keys ...tea.KeyMsg) formModel {
+	for _, key := range keys {
+		newModel, _ := m.Update(key)
+		m = newModel.(formModel)
+	}
+	return m
+}
+
+// 送信時と同じく表示するフィールドを検証し、最初のエラーを返す
+func checkFormFields(m formModel) error {
+	m, _, _ = m.checkFields(m.visibleFields())
+	for _, i := range m.visibleFields() {
+		if err := m.fields[i].err; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	formKeyUp    = tea.KeyMsg{Type: tea.KeyUp}
+	formKeyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	formKeySpace = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	formKeyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	formKeyTab   = tea.KeyMsg{Type: tea.KeyTab}
+)
+
+func TestFormFieldKinds(t *testing.T) {
+	t.Run("単一選択は↑/↓で移動してEnterで選んで次へ", func(t *testing.T) {
+		m := sendFormKeys(newAllKindsForm(), formKeyDown, formKeyEnter)
+
+		if got := m.fields[0].value(); got != "有料" {
+			t.Errorf("カーソル位置の選択肢が選ばれるべき、実際: %q", got)
+		}
+		if m.focusIndex != 1 {
+			t.Errorf("次のフィールドへ移動するべき、実際: %d", m.focusIndex)
+		}
+	})
+
+	t.Run("複数選択はSpaceで切り替え", func(t *testing.T) {
+		m := sendFormKeys(newAllKindsForm(), formKeyTab, formKeySpace, formKeyDown, formKeyDown, formKeySpace)
+
+		if got := m.getFormData()["langs"]; !slices.Equal(got.([]string), []string{"Go", "Python"}) {
+			t.Errorf("選んだ選択肢が入力データになるべき、実際: %v", got)
+		}
+		if m.focusIndex != 1 {
+			t.Errorf("↑/↓ はフィールドを移動しないべき、実際: %d", m.focusIndex)
+		}
+	})
+
+	t.Run("チェックボックス", func(t *testing.T) {
+		m := newAllKindsForm().focus(2)
+		if err := m.fields[2].spec.validate(m.fields[2].value()); err == nil || !strings.Contains(err.Error(), "チェック") {
+			t.Errorf("必須のチェックボックスは未チェックでエラーになるべき、実際: %v", err)
+		}
+
+		m = sendFormKeys(m, formKeySpace)
+		if m.getFormData()["agree"] != true || !strings.Contains(m.View(), "[x] 規約に同意する") {
+			t.Errorf("チェックされるべき:\n%s", m.View())
+		}
+	})
+
+	t.Run("複数行テキストはEnterで改行", func(t *testing.T) {
+		m := newAllKindsForm().focus(3)
+		m = sendFormKeys(m, runeKey("a"), formKeyEnter, runeKey("b"))
+
+		if got := m.getFormData()["bio"]; got != "a\nb" {
+			t.Errorf("改行を含む値になるべき、実際: %q", got)
+		}
+		if m.focusIndex != 3 {
+			t.Errorf("Enterでフィールドを移動しないべき、実際: %d", m.focusIndex)
+		}
+	})
+
+	t.Run("数値は数字だけ入力して範囲を検証", func(t *testing.T) {
+		m := newAllKindsForm().focus(4)
+		m = sendFormKeys(m, runeKey("2"), runeKey("a"), runeKey("0"), runeKey("0"))
+
+		if got := m.fields[4].value(); got != "200" {
+			t.Errorf("数字以外は入力されないべき、実際: %q", got)
+		}
+		if err := m.fields[4].spec.validate("200"); err == nil || !strings.Contains(err.Error(), "150以下") {
+			t.Errorf("上限を超えるとエラーになるべき、実際: %v", err)
+		}
+		if err := m.fields[4].spec.validate("1-2"); err == nil || !strings.Contains(err.Error(), "数値") {
+			t.Errorf("数値でなければエラーになるべき、実際: %v", err)
+		}
+
+		m.fields[4] = m.fields[4].setValue("42")
+		if got := m.getFormData()["age"]; got != 42.0 {
+			t.Errorf("数値が入力データになるべき、実際: %v", got)
+		}
+	})
+
+	t.Run("日付は今日から年・月・日を増減", func(t *testing.T) {
+		m := newAllKindsForm().focus(5)
+		if got := m.getFormData()["birthday"]; got != "" {
+			t.Errorf("未選択は空であるべき、実際: %v", got)
+		}
+
+		left := tea.KeyMsg{Type: tea.KeyLeft}
+		m = sendFormKeys(m, formKeyUp, formKeyUp, left, formKeyDown, left, formKeyDown)
+		if got := m.getFormData()["birthday"]; got != "2025-09-18" {
+			t.Errorf("年・月・日が増減されるべき、実際: %v", got)
+		}
+
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+		if got := m.fields[5].value(); got != "" {
+			t.Errorf("Backspaceで未選択に戻るべき、実際: %q", got)
+		}
+	})
+
+	t.Run("パスワードは伏せて表示", func(t *testing.T) {
+		m := newAllKindsForm().focus(6)
+		m = sendFormKeys(m, runeKey("s"), runeKey("e"), runeKey("c"))
+
+		if view := m.View(); strings.Contains(view, "sec") || !strings.Contains(view, "•••") {
+			t.Errorf("入力中も伏せ字で表示されるべき:\n%s", view)
+		}
+		if m.getFormData()["password"] != "sec" {
+			t.Error("入力データは実際の値であるべき")
+		}
+	})
+
+	t.Run("送信時に全てのフィールドを検証", func(t *testing.T) {
+		m := newAllKindsForm()
+		if err := checkFormFields(m); err == nil || !strings.Contains(err.Error(), "プランを選択") {
+			t.Errorf("未選択の必須フィールドでエラーになるべき、実際: %v", err)
+		}
+
+		m.fields[0] = m.fields[0].setValue("無料")
+		m.fields[1] = m.fields[1].setValue("Rust, Go")
+		m.fields[2] = m.fields[2].setValue("true")
+		m.fields[5] = m.fields[5].setValue("2000-01-02")
+		m.fields[6] = m.fields[6].setValue("secret")
+		m = sendFormKeys(m.focus(m.submitIndex()), formKeyEnter)
+
+		if m.state != formSubmitted {
+			t.Fatalf("送信されるべき、エラー: %s", m.errorMessage)
+		}
+		view := m.View()
+		for _, want := range []string{"無料", "Go, Rust", "はい", "2000-01-02", "••••••"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("送信完了画面に「%s」が含まれているべき:\n%s", want, view)
+			}
+		}
+		if strings.Contains(view, "secret") {
+			t.Error("パスワードは表示しないべき")
+		}
+	})
+}
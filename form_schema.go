@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// フォームの定義（フィールドの並びと種類）からformModelを組み立てる。
+// 新しいフォームは formSchema を書くだけで作れ、移動・検証・描画はformModelが共通で行う。
+
+// フィールドの種類
+type formFieldKind int
+
+const (
+	fieldText        formFieldKind = iota // 1行のテキスト
+	fieldEmail                            // メールアドレス（形式を検証する）
+	fieldPassword                         // 伏せ字で表示するテキスト
+	fieldNumber                           // 数値（min/maxで範囲を検証する）
+	fieldTextarea                         // 複数行のテキスト
+	fieldSelect                           // 選択肢から1つ選ぶ
+	fieldMultiSelect                      // 選択肢から複数選ぶ
+	fieldCheckbox                         // はい/いいえ（必須の場合はチェックが必要）
+	fieldDate                             // 日付（YYYY-MM-DD）
+)
+
+// 入力値の検証（問題がなければnil）
+type formValidator func(value string) error
+
+// フィールドの定義
+type formFieldSpec struct {
+	id          string             // 入力データのキー
+	kind        formFieldKind      // 種類
+	label       string             // 表示名（エラーメッセージにも使う）
+	placeholder string             // 未入力時のヒント
+	required    bool               // 入力必須か
+	maxLength   int                // 最大の長さ（0の場合は constants.FormFieldMaxLength）
+	lengthUnit  formLengthUnit     // maxLength の単位（文字数・表示幅）
+	options     []string           // 選択肢（単一選択・複数選択）
+	min         *float64           // 数値の下限（nilの場合は制限しない）
+	max         *float64           // 数値の上限（nilの場合は制限しない）
+	validators  []formValidator    // 追加の検証（前後の空白を除いた値を渡す、空の場合は呼ばない）
+	async       formAsyncValidator // 非同期の検証（他の検証を全て通った場合だけ実行する）
+	defaultVal  string             // 既定値（formField.setValue で解釈する）
+	defaultOpts []string           // 複数選択の既定値（カンマを含む選択肢もそのまま選べる）
+}
+
+// フォームの定義
+type formSchema struct {
+	id          string // フォームの識別子
+	title       string // 入力画面の見出し
+	submitLabel string // 送信ボタンの表示（空の場合は「送信」）
+	fields      []formFieldSpec
+	pages       []formPage // ページ（空の場合は全てのフィールドを1ページに表示する）
+}
+
+// 種類ごとの組み込みの検証
+var formKindValidators = map[formFieldKind][]formValidator{
+	fieldEmail: {validateEmail},
+}
+
+// メールアドレスの形式
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// メールアドレスの形式を検証
+func validateEmail(value string) error {
+	if !emailRegex.MatchString(value) {
+		return errors.New("正しいメールアドレスの形式で入力してください")
+	}
+	return nil
+}
+
+// フィールドの値を検証（必須チェック → 種類ごとの検証 → 追加の検証の順）
+func (f formFieldSpec) validate(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if !f.required {
+			return nil
+		}
+		switch f.kind {
+		case fieldSelect, fieldMultiSelect, fieldDate:
+			return fmt.Errorf("%sを選択してください", f.label)
+		case fieldCheckbox:
+			return fmt.Errorf("%sにチェックを入れてください", f.label)
+		}
+		return fmt.Errorf("%sを入力してください", f.label)
+	}
+	// 既定値・下書きなど入力欄を通さずに設定した値も長さを確かめる
+	if f.kind.textual() && f.lengthUnit.measure(value) > f.charLimit() {
+		return fmt.Errorf("%sは%sで入力してください", f.label, f.lengthUnit.limitText(f.charLimit()))
+	}
+	if f.kind == fieldNumber {
+		if err := f.validateNumber(value); err != nil {
+			return err
+		}
+	}
+	for _, validators := range [][]formValidator{formKindValidators[f.kind], f.validators} {
+		for _, v := range validators {
+			if err := v(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 数値の形式と範囲を検証
+func (f formFieldSpec) validateNumber(value string) error {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%sは数値で入力してください", f.label)
+	}
+	if f.min != nil && n < *f.min {
+		return fmt.Errorf("%sは%s以上で入力してください", f.label, formatFormNumber(*f.min))
+	}
+	if f.max != nil && n > *f.max {
+		return fmt.Errorf("%sは%s以下で入力してください", f.label, formatFormNumber(*f.max))
+	}
+	return nil
+}
+
+// 数値の表示（整数は小数点なし）
+func formatFormNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// 文字を入力する種類か
+func (k formFieldKind) textual() bool {
+	switch k {
+	case fieldText, fieldEmail, fieldPassword, fieldNumber, fieldTextarea:
+		return true
+	}
+	return false
+}
+
+// 最大の長さ（lengthUnit で数える）
+func (f formFieldSpec) charLimit() int {
+	if f.maxLength > 0 {
+		return f.maxLength
+	}
+	return constants.FormFieldMaxLength
+}
+
+// ユーザー登録フォーム（`go run . form` の既定のフォーム）
+func registrationFormSchema() formSchema {
+	return formSchema{
+		id:    "registration",
+		title: "📝 ユーザー登録フォーム",
+		fields: []formFieldSpec{
+			{
+				id:          "name",
+				kind:        fieldText,
+				label:       "名前",
+				placeholder: "例: 山田太郎",
+				required:    true,
+			},
+			{
+				id:          "email",
+				kind:        fieldEmail,
+				label:       "メールアドレス",
+				placeholder: "例: taro@example.com",
+				required:    true,
+				maxLength:   constants.EmailMaxLength,
+			},
+		},
+	}
+}
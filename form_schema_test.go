@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用のアンケートフォーム
+func surveyFormSchema() formSchema {
+	return formSchema{
+		id:          "survey",
+		title:       "📋 アンケート",
+		submitLabel: "回答する",
+		fields: []formFieldSpec{
+			{id: "team", label: "チーム", required: true},
+			{id: "rating", label: "評価", validators: []formValidator{
+				func(v string) error {
+					if v != "良い" && v != "悪い" {
+						return errors.New("評価は「良い」か「悪い」で入力してください")
+					}
+					return nil
+				},
+			}},
+			{id: "comment", label: "コメント", maxLength: 200},
+		},
+	}
+}
+
+func TestFormSchema(t *testing.T) {
+	t.Run("定義したフィールドを描画", func(t *testing.T) {
+		m := NewFormModelWithSchema(surveyFormSchema())
+
+		view := m.View()
+		for _, want := range []string{"アンケート", "チーム:", "評価:", "コメント:", "[ 回答する ]"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("ビューに「%s」が含まれているべき:\n%s", want, view)
+			}
+		}
+		if m.fields[2].spec.charLimit() != 200 {
+			t.Errorf("最大文字数が設定されるべき、実際: %d", m.fields[2].spec.charLimit())
+		}
+	})
+
+	t.Run("フィールド数に合わせて移動", func(t *testing.T) {
+		m := NewFormModelWithSchema(surveyFormSchema())
+		for range 3 {
+			m = m.nextField()
+		}
+		if m.focusIndex != m.submitIndex() || m.submitIndex() != 3 {
+			t.Errorf("3つ進むと送信ボタンになるべき、実際: %d", m.focusIndex)
+		}
+		m = m.nextField()
+		if m.focusIndex != 0 || !m.fields[0].input.Focused() {
+			t.Errorf("送信ボタンの次は先頭になるべき、実際: %d", m.focusIndex)
+		}
+		m = m.prevField()
+		if m.focusIndex != 3 {
+			t.Errorf("先頭の前は送信ボタンになるべき、実際: %d", m.focusIndex)
+		}
+	})
+
+	t.Run("必須と追加の検証", func(t *testing.T) {
+		m := NewFormModelWithSchema(surveyFormSchema())
+		if err := checkFormFields(m); err == nil || !strings.Contains(err.Error(), "チーム") {
+			t.Errorf("必須のフィールドが空ならエラーになるべき、実際: %v", err)
+		}
+
+		m.fields[0].input.SetValue("開発")
+		if err := checkFormFields(m); err != nil {
+			t.Errorf("任意のフィールドは空でもよいべき、実際: %v", err)
+		}
+
+		m.fields[1].input.SetValue("普通")
+		if err := checkFormFields(m); err == nil || !strings.Contains(err.Error(), "評価は") {
+			t.Errorf("追加の検証のエラーになるべき、実際: %v", err)
+		}
+	})
+
+	t.Run("送信するとIDごとの値を表示", func(t *testing.T) {
+		m := NewFormModelWithSchema(surveyFormSchema())
+		m.fields[0].input.SetValue("開発")
+		m.fields[2].input.SetValue(" 特になし ")
+		m = m.focus(m.submitIndex())
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = newModel.(formModel)
+		if m.state != formSubmitted {
+			t.Fatalf("送信されるべき、エラー: %s", m.errorMessage)
+		}
+		data := m.getFormData()
+		if data["team"] != "開発" || data["rating"] != "" || data["comment"] != "特になし" {
+			t.Errorf("IDごとの値になるべき、実際: %v", data)
+		}
+		if view := m.View(); !strings.Contains(view, "コメント:") || !strings.Contains(view, "特になし") {
+			t.Errorf("送信した値が表示されるべき:\n%s", view)
+		}
+	})
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 送信した入力データ
+type formSubmission struct {
+	form   string    // フォームの識別子
+	fields []string  // フィールドIDの並び（CSVの列順）
+	data   formData  // フィールドIDごとの値
+	at     time.Time // 送信時刻
+}
+
+// 送信先を抽象化するインターフェース
+type formSink interface {
+	// Submit - 入力データを送信する
+	Submit(s formSubmission) error
+}
+
+// 終了時に書き出す送信先
+type formFlusher interface {
+	// Flush - 送信済みのデータを書き出す
+	Flush() error
+}
+
+// 送信が完了した時のメッセージ
+type formSentMsg struct {
+	err error
+}
+
+// 送信先に送るコマンド
+func submitFormCmd(sink formSink, s formSubmission) tea.Cmd {
+	return func() tea.Msg {
+		return formSentMsg{err: sink.Submit(s)}
+	}
+}
+
+// ファイル上の送信データ（1行に1件）
+type formSubmissionRecord struct {
+	Form        string   `json:"form"`
+	SubmittedAt string   `json:"submitted_at"` // RFC 3339
+	Answers     formData `json:"answers"`
+}
+
+// 送信データをファイル上の表現に変換
+func newFormSubmissionRecord(s formSubmission) formSubmissionRecord {
+	return formSubmissionRecord{
+		Form:        s.form,
+		SubmittedAt: s.at.Format(time.RFC3339),
+		Answers:     s.data,
+	}
+}
+
+// JSON Lines のファイルに追記する送信先
+type jsonFileFormSink struct {
+	path string
+}
+
+// Submit - 1件を1行のJSONとして追記
+func (s jsonFileFormSink) Submit(sub formSubmission) error {
+	return storage.AppendJSONLine(s.path, newFormSubmissionRecord(sub))
+}
+
+// CSVファイルに追記する送信先（新しいファイルには見出し行を書き、既存のファイルは見出し行が一致する場合だけ追記する）
+type csvFileFormSink struct {
+	path string
+}
+
+// CSVのセルの表現
+func formCSVValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return formatFormNumber(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// 既存のCSVの見出し行が header と一致するかを確かめる
+func checkFormCSVHeader(r io.Reader, header []string) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	existing, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("既存のCSVの見出し行を読み込めません: %w", err)
+	}
+	if !slices.Equal(existing, header) {
+		return fmt.Errorf("既存のCSVの列（%s）がフォームの項目（%s）と一致しません。別のファイルを指定してください",
+			strings.Join(existing, ", "), strings.Join(header, ", "))
+	}
+	return nil
+}
+
+// Submit - 1件を1行として追記
+func (s csvFileFormSink) Submit(sub formSubmission) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("ディレクトリの作成に失敗: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("ファイルを開けません: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("ファイルの情報を取得できません: %w", err)
+	}
+
+	header := append([]string{"submitted_at"}, sub.fields...)
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if info.Size() == 0 {
+		w.Write(header)
+	} else if err := checkFormCSVHeader(f, header); err != nil {
+		// 別のフォームの列の下に追記しない
+		f.Close()
+		return err
+	}
+	row := []string{sub.at.Format(time.RFC3339)}
+	for _, id := range sub.fields {
+		row = append(row, formCSVValue(sub.data[id]))
+	}
+	w.Write(row)
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("CSVエンコードに失敗: %w", err)
+	}
+
+	// 1件を1回の Write で書き込み、途中まで書かれた行が残りにくいようにする
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("ファイルへの追記に失敗: %w", err)
+	}
+	return f.Close()
+}
+
+// HTTPエンドポイントにJSONをPOSTする送信先
+type httpFormSink struct {
+	url    string
+	client *http.Client
+}
+
+// HTTPの送信先を生成
+func newHTTPFormSink(url string) httpFormSink {
+	return httpFormSink{url: url, client: &http.Client{Timeout: constants.FormSubmitTimeout}}
+}
+
+// Submit - JSONをPOSTし、2xx以外はエラー
+func (s httpFormSink) Submit(sub formSubmission) error {
+	body, err := json.Marshal(newFormSubmissionRecord(sub))
+	if err != nil {
+		return fmt.Errorf("JSONエンコードに失敗: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.AppName)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ネットワークエラー: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("送信エラー: ステータスコード %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// 終了時に標準出力へ回答のJSONを書き出す送信先
+type stdoutFormSink struct {
+	w       io.Writer
+	answers *formData // 最後に送信した回答（Flushまで保持する）
+}
+
+// 標準出力の送信先を生成
+func newStdoutFormSink(w io.Writer) *stdoutFormSink {
+	return &stdoutFormSink{w: w}
+}
+
+// Submit - 回答を保持する（TUIの終了後に Flush で書き出す）
+func (s *stdoutFormSink) Submit(sub formSubmission) error {
+	s.answers = &sub.data
+	return nil
+}
+
+// Flush - 保持している回答を書き出す（未送信の場合は何もしない）
+func (s *stdoutFormSink) Flush() error {
+	if s.answers == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(*s.answers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("JSONエンコードに失敗: %w", err)
+	}
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// 回答のJSONでファイルを上書きする送信先
+type answersFileFormSink struct {
+	path string
+}
+
+// Submit - 回答をJSONで書き込む
+func (s answersFileFormSink) Submit(sub formSubmission) error {
+	return storage.SaveJSON(s.path, sub.data)
+}
+
+// `form` サブコマンドのオプション
+type formOptions struct {
+	specPath string   // フォーム定義のファイル（空の場合はユーザー登録フォーム）
+	sink     formSink // 送信先（nilの場合は送信しない）
+}
+
+// `form` サブコマンドの引数を読み込む。
+// フォーム定義を指定した場合、送信先の指定がなければ標準出力に回答を書き出す。
+func parseFormOptions(args []string, w io.Writer, stdout io.Writer) (formOptions, error) {
+	fs := flag.NewFlagSet("form", flag.ContinueOnError)
+	fs.SetOutput(w)
+	specPath := fs.String("spec", "", "フォーム定義のファイル（YAML / JSON）")
+	jsonPath := fs.String("json", "", "回答を追記するJSON Linesファイル")
+	csvPath := fs.String("csv", "", "回答を追記するCSVファイル")
+	postURL := fs.String("post", "", "回答をJSONでPOSTするURL")
+	outPath := fs.String("out", "", "回答をJSONで書き込むファイル")
+	toStdout := fs.Bool("stdout", false, "終了時に回答をJSONで標準出力に書き出す")
+	if err := fs.Parse(args); err != nil {
+		return formOptions{}, err
+	}
+
+	var sinks []formSink
+	if *jsonPath != "" {
+		sinks = append(sinks, jsonFileFormSink{path: *jsonPath})
+	}
+	if *csvPath != "" {
+		sinks = append(sinks, csvFileFormSink{path: *csvPath})
+	}
+	if *postURL != "" {
+		sinks = append(sinks, newHTTPFormSink(*postURL))
+	}
+	if *outPath != "" {
+		sinks = append(sinks, answersFileFormSink{path: *outPath})
+	}
+	if *toStdout || (*specPath != "" && len(sinks) == 0) {
+		sinks = append(sinks, newStdoutFormSink(stdout))
+	}
+
+	opts := formOptions{specPath: *specPath}
+	switch len(sinks) {
+	case 0:
+	case 1:
+		opts.sink = sinks[0]
+	default:
+		// リトライで成功済みの送信先に重複して送らないように、送信先は1つに限る
+		return formOptions{}, errors.New("--json / --csv / --post / --out / --stdout はいずれか1つだけ指定してください")
+	}
+	return opts, nil
+}
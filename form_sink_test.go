@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 失敗する回数を指定できるテスト用の送信先
+type fakeFormSink struct {
+	failures int
+	sent     []formSubmission
+}
+
+func (s *fakeFormSink) Submit(sub formSubmission) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("接続できません")
+	}
+	s.sent = append(s.sent, sub)
+	return nil
+}
+
+var formSinkTestTime = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+func testFormSubmission() formSubmission {
+	return formSubmission{
+		form:   "survey",
+		fields: []string{"team", "langs", "agree", "age"},
+		data:   formData{"team": "開発, 運用", "langs": []string{"Go", "Rust"}, "agree": true, "age": 42.0},
+		at:     formSinkTestTime,
+	}
+}
+
+// 送信先を指定した入力済みの登録フォーム
+func newFilledFormWithSink(sink formSink) formModel {
+	m := NewFormModelWithSink(registrationFormSchema(), sink)
+	m.now = func() time.Time { return formSinkTestTime }
+	m.fields[regNameField].input.SetValue("山田太郎")
+	m.fields[regEmailField].input.SetValue("taro@example.com")
+	return m.focus(regSubmitButton)
+}
+
+// 送信のコマンドを実行して結果を反映
+func runFormSend(t *testing.T, m formModel, cmd tea.Cmd) formModel {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("送信コマンドが返されるべき")
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(formSentMsg); ok {
+			newModel, _ := m.Update(msg)
+			return newModel.(formModel)
+		}
+	}
+	t.Fatal("送信結果のメッセージが返されるべき")
+	return m
+}
+
+func TestFormSinks(t *testing.T) {
+	t.Run("JSON Linesに追記", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.jsonl")
+		sink := jsonFileFormSink{path: path}
+		sink.Submit(testFormSubmission())
+		sink.Submit(testFormSubmission())
+
+		data, _ := os.ReadFile(path)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("1件1行で追記されるべき、実際: %q", data)
+		}
+		var record formSubmissionRecord
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+			t.Fatalf("JSONとして読めるべき: %v", err)
+		}
+		if record.Form != "survey" || record.SubmittedAt != "2026-10-17T09:00:00Z" || record.Answers["age"] != 42.0 {
+			t.Errorf("送信データが記録されるべき、実際: %+v", record)
+		}
+	})
+
+	t.Run("CSVに追記（見出し行は最初だけ）", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.csv")
+		sink := csvFileFormSink{path: path}
+		sink.Submit(testFormSubmission())
+		sink.Submit(testFormSubmission())
+
+		data, _ := os.ReadFile(path)
+		want := "submitted_at,team,langs,agree,age\n" +
+			"2026-10-17T09:00:00Z,\"開発, 運用\",\"Go, Rust\",true,42\n" +
+			"2026-10-17T09:00:00Z,\"開発, 運用\",\"Go, Rust\",true,42\n"
+		if string(data) != want {
+			t.Errorf("CSVの内容が違う:\n%s", data)
+		}
+	})
+
+	t.Run("見出し行が違うCSVには追記しない", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.csv")
+		sink := csvFileFormSink{path: path}
+		sink.Submit(testFormSubmission())
+
+		changed := testFormSubmission()
+		changed.fields = append(changed.fields, "comment")
+		err := sink.Submit(changed)
+		if err == nil || !strings.Contains(err.Error(), "一致しません") {
+			t.Errorf("列が違う場合はエラーになるべき、実際: %v", err)
+		}
+		if data, _ := os.ReadFile(path); strings.Count(string(data), "\n") != 2 {
+			t.Errorf("追記しないべき:\n%s", data)
+		}
+	})
+
+	t.Run("HTTPでPOST", func(t *testing.T) {
+		var got formSubmissionRecord
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		if err := newHTTPFormSink(server.URL).Submit(testFormSubmission()); err != nil {
+			t.Fatalf("送信に成功するべき: %v", err)
+		}
+		if got.Form != "survey" || got.Answers["team"] != "開発, 運用" {
+			t.Errorf("JSONが送られるべき、実際: %+v", got)
+		}
+	})
+
+	t.Run("HTTPのエラー", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		if err := newHTTPFormSink(server.URL).Submit(testFormSubmission()); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("ステータスコードのエラーになるべき、実際: %v", err)
+		}
+	})
+
+	t.Run("標準出力には終了時に書き出す", func(t *testing.T) {
+		var buf bytes.Buffer
+		sink := newStdoutFormSink(&buf)
+		sink.Flush()
+		if buf.Len() != 0 {
+			t.Error("未送信の場合は何も書き出さないべき")
+		}
+
+		sink.Submit(testFormSubmission())
+		if buf.Len() != 0 {
+			t.Error("送信時には書き出さないべき")
+		}
+		sink.Flush()
+		var answers map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &answers); err != nil || answers["agree"] != true {
+			t.Errorf("回答がJSONで書き出されるべき、実際: %s", buf.String())
+		}
+	})
+
+	t.Run("引数の読み込み", func(t *testing.T) {
+		opts, err := parseFormOptions([]string{"--csv", "a.csv"}, io.Discard, io.Discard)
+		if _, ok := opts.sink.(csvFileFormSink); !ok || err != nil {
+			t.Errorf("CSVの送信先になるべき、実際: %T (%v)", opts.sink, err)
+		}
+		if opts, err := parseFormOptions(nil, io.Discard, io.Discard); opts.sink != nil || err != nil {
+			t.Errorf("指定がなければnilであるべき、実際: %T (%v)", opts.sink, err)
+		}
+		if _, err := parseFormOptions([]string{"--csv", "a.csv", "--stdout"}, io.Discard, io.Discard); err == nil {
+			t.Error("複数の送信先はエラーになるべき")
+		}
+	})
+
+	t.Run("フォーム定義を指定すると標準出力に書き出す", func(t *testing.T) {
+		opts, err := parseFormOptions([]string{"--spec", "survey.yaml"}, io.Discard, io.Discard)
+		if _, ok := opts.sink.(*stdoutFormSink); !ok || opts.specPath != "survey.yaml" || err != nil {
+			t.Errorf("標準出力の送信先になるべき、実際: %+v (%v)", opts, err)
+		}
+		opts, _ = parseFormOptions([]string{"--spec", "survey.yaml", "--out", "answer.json"}, io.Discard, io.Discard)
+		if _, ok := opts.sink.(answersFileFormSink); !ok {
+			t.Errorf("指定した送信先を使うべき、実際: %T", opts.sink)
+		}
+	})
+
+	t.Run("回答のJSONでファイルを上書き", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answer.json")
+		sink := answersFileFormSink{path: path}
+		sink.Submit(formSubmission{data: formData{"name": "古い回答"}})
+		sink.Submit(testFormSubmission())
+
+		var answers map[string]any
+		data, _ := os.ReadFile(path)
+		if err := json.Unmarshal(data, &answers); err != nil || answers["team"] != "開発, 運用" || answers["name"] != nil {
+			t.Errorf("最後の回答だけが書き込まれるべき、実際: %s", data)
+		}
+	})
+}
+
+func TestFormSubmitStates(t *testing.T) {
+	t.Run("送信中を経て完了", func(t *testing.T) {
+		sink := &fakeFormSink{}
+		newModel, cmd := newFilledFormWithSink(sink).Update(formKeyEnter)
+		m := newModel.(formModel)
+		if m.state != formSubmitting || !strings.Contains(m.View(), "送信中") {
+			t.Fatalf("送信中の表示になるべき:\n%s", m.View())
+		}
+
+		m = runFormSend(t, m, cmd)
+		if m.state != formSubmitted || len(sink.sent) != 1 {
+			t.Fatalf("送信が完了するべき、実際: %d", m.state)
+		}
+		sent := sink.sent[0]
+		if sent.form != "registration" || sent.data["name"] != "山田太郎" || strings.Join(sent.fields, ",") != "name,email" {
+			t.Errorf("フォームの入力データが送られるべき、実際: %+v", sent)
+		}
+	})
+
+	t.Run("失敗したらリトライできる", func(t *testing.T) {
+		sink := &fakeFormSink{failures: 1}
+		newModel, cmd := newFilledFormWithSink(sink).Update(formKeyEnter)
+		m := runFormSend(t, newModel.(formModel), cmd)
+		if m.state != formSubmitError || !strings.Contains(m.View(), "接続できません") {
+			t.Fatalf("エラー画面になるべき:\n%s", m.View())
+		}
+
+		newModel, cmd = m.Update(formKeyEnter)
+		m = runFormSend(t, newModel.(formModel), cmd)
+		if m.state != formSubmitted || m.retryCount != 1 {
+			t.Errorf("リトライで送信が完了するべき、実際: %d (%d回)", m.state, m.retryCount)
+		}
+	})
+
+	t.Run("リトライの上限とEscで入力に戻る", func(t *testing.T) {
+		sink := &fakeFormSink{failures: 100}
+		newModel, cmd := newFilledFormWithSink(sink).Update(formKeyEnter)
+		m := runFormSend(t, newModel.(formModel), cmd)
+		for range m.maxRetries {
+			newModel, cmd = m.Update(formKeyEnter)
+			m = runFormSend(t, newModel.(formModel), cmd)
+		}
+
+		if _, cmd := m.Update(formKeyEnter); cmd != nil || !strings.Contains(m.View(), "上限") {
+			t.Errorf("上限に達したらリトライできないべき:\n%s", m.View())
+		}
+
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = newModel.(formModel)
+		if m.state != formInput || m.fields[regNameField].value() != "山田太郎" {
+			t.Error("入力内容を残したまま入力画面に戻るべき")
+		}
+	})
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// フォームの定義ファイル（YAML / JSON）を読み込んで formSchema に変換する。
+// `go run . form --spec survey.yaml` で、任意のフォームを対話的な入力に使える。
+//
+//	id: survey
+//	title: アンケート
+//	fields:
+//	  - id: name
+//	    label: 名前
+//	    required: true
+//	    min_length: 2
+//	  - id: team
+//	    type: select
+//	    options: [開発, 運用]
+//	    default: 開発
+//
+// 複数ページのフォームは fields の代わりに pages を書く。
+// when を指定したページは、前のページのフィールドの値が equals に一致する場合だけ表示する。
+//
+//	pages:
+//	  - title: 基本情報
+//	    fields: [...]
+//	  - title: 開発チーム
+//	    when: {field: team, equals: 開発}
+//	    fields: [...]
+
+// 定義ファイルのフォーム
+type formSpecFile struct {
+	ID     string          `json:"id" yaml:"id"`
+	Title  string          `json:"title" yaml:"title"`
+	Submit string          `json:"submit" yaml:"submit"` // 送信ボタンの表示
+	Fields []formSpecField `json:"fields" yaml:"fields"`
+	Pages  []formSpecPage  `json:"pages" yaml:"pages"` // 複数ページのフォーム
+}
+
+// 定義ファイルのページ
+type formSpecPage struct {
+	Title  string             `json:"title" yaml:"title"`
+	When   *formSpecCondition `json:"when" yaml:"when"`
+	Fields []formSpecField    `json:"fields" yaml:"fields"`
+}
+
+// 定義ファイルのページの表示条件
+type formSpecCondition struct {
+	Field  string `json:"field" yaml:"field"`
+	Equals any    `json:"equals" yaml:"equals"` // 一致する値（リストの場合はいずれか）
+}
+
+// 定義ファイルのフィールド
+type formSpecField struct {
+	ID             string   `json:"id" yaml:"id"`
+	Type           string   `json:"type" yaml:"type"` // 省略時は text
+	Label          string   `json:"label" yaml:"label"`
+	Placeholder    string   `json:"placeholder" yaml:"placeholder"`
+	Required       bool     `json:"required" yaml:"required"`
+	MinLength      int      `json:"min_length" yaml:"min_length"`
+	MaxLength      int      `json:"max_length" yaml:"max_length"`
+	LengthUnit     string   `json:"length_unit" yaml:"length_unit"`         // max_length の単位（省略時は graphemes）
+	Pattern        string   `json:"pattern" yaml:"pattern"`                 // 正規表現
+	PatternMessage string   `json:"pattern_message" yaml:"pattern_message"` // 一致しない場合のエラー
+	Options        []string `json:"options" yaml:"options"`
+	Min            *float64 `json:"min" yaml:"min"`
+	Max            *float64 `json:"max" yaml:"max"`
+	Available      string   `json:"available" yaml:"available"` // ユーザー名の使用状況を問い合わせるURL
+	Default        any      `json:"default" yaml:"default"`
+}
+
+// 定義ファイルの種類名
+var formFieldKindNames = map[string]formFieldKind{
+	"text":        fieldText,
+	"email":       fieldEmail,
+	"password":    fieldPassword,
+	"number":      fieldNumber,
+	"textarea":    fieldTextarea,
+	"select":      fieldSelect,
+	"multiselect": fieldMultiSelect,
+	"checkbox":    fieldCheckbox,
+	"date":        fieldDate,
+}
+
+// 定義ファイルの長さの単位名
+var formLengthUnitNames = map[string]formLengthUnit{
+	"":          lengthGraphemes,
+	"graphemes": lengthGraphemes,
+	"cells":     lengthCells,
+}
+
+// 定義ファイルを読み込む（拡張子が .yaml / .yml ならYAML、それ以外はJSON）
+func loadFormSchema(path string) (formSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return formSchema{}, err
+	}
+
+	var spec formSpecFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&spec)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&spec)
+	}
+	if err != nil {
+		return formSchema{}, fmt.Errorf("フォーム定義のパースエラー (%s): %w", path, err)
+	}
+
+	if spec.ID == "" {
+		spec.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	schema, err := spec.schema()
+	if err != nil {
+		return formSchema{}, fmt.Errorf("フォーム定義のエラー (%s): %w", path, err)
+	}
+	return schema, nil
+}
+
+// 定義を検証して formSchema に変換
+func (s formSpecFile) schema() (formSchema, error) {
+	switch {
+	case len(s.Fields) > 0 && len(s.Pages) > 0:
+		return formSchema{}, errors.New("fields と pages は同時に指定できません")
+	case len(s.Fields) == 0 && len(s.Pages) == 0:
+		return formSchema{}, errors.New("フィールドがありません")
+	}
+	schema := formSchema{id: s.ID, title: s.Title, submitLabel: s.Submit}
+	if schema.title == "" {
+		schema.title = "📝 " + s.ID
+	}
+
+	seen := map[string]bool{}
+	addFields := func(fields []formSpecField) error {
+		for _, f := range fields {
+			if f.ID == "" {
+				return fmt.Errorf("%d番目のフィールドに id がありません", len(schema.fields)+1)
+			}
+			if seen[f.ID] {
+				return fmt.Errorf("フィールド「%s」が重複しています", f.ID)
+			}
+			seen[f.ID] = true
+
+			field, err := f.spec()
+			if err != nil {
+				return fmt.Errorf("フィールド「%s」: %w", f.ID, err)
+			}
+			schema.fields = append(schema.fields, field)
+		}
+		return nil
+	}
+
+	if err := addFields(s.Fields); err != nil {
+		return formSchema{}, err
+	}
+	for i, p := range s.Pages {
+		if len(p.Fields) == 0 {
+			return formSchema{}, fmt.Errorf("%d番目のページにフィールドがありません", i+1)
+		}
+		page := formPage{title: p.Title}
+		if page.title == "" {
+			page.title = fmt.Sprintf("ページ %d", i+1)
+		}
+		if p.When != nil {
+			// 条件に使えるのは前のページのフィールドだけ
+			if !seen[p.When.Field] {
+				return formSchema{}, fmt.Errorf("%d番目のページの条件のフィールド「%s」が前のページにありません", i+1, p.When.Field)
+			}
+			values := formSpecValues(p.When.Equals)
+			if len(values) == 0 {
+				return formSchema{}, fmt.Errorf("%d番目のページの条件に equals がありません", i+1)
+			}
+			page.when = &formCondition{field: p.When.Field, values: values}
+		}
+
+		start := len(schema.fields)
+		if err := addFields(p.Fields); err != nil {
+			return formSchema{}, err
+		}
+		for _, f := range schema.fields[start:] {
+			page.fields = append(page.fields, f.id)
+		}
+		schema.pages = append(schema.pages, page)
+	}
+	return schema, nil
+}
+
+// フィールドの定義を検証して formFieldSpec に変換
+func (f formSpecField) spec() (formFieldSpec, error) {
+	if f.Type == "" {
+		f.Type = "text"
+	}
+	kind, ok := formFieldKindNames[f.Type]
+	if !ok {
+		return formFieldSpec{}, fmt.Errorf("種類 %q は不明です", f.Type)
+	}
+	if (kind == fieldSelect || kind == fieldMultiSelect) && len(f.Options) == 0 {
+		return formFieldSpec{}, errors.New("options がありません")
+	}
+	unit, ok := formLengthUnitNames[f.LengthUnit]
+	if !ok {
+		return formFieldSpec{}, fmt.Errorf("length_unit %q は不明です（graphemes / cells）", f.LengthUnit)
+	}
+	if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+		return formFieldSpec{}, errors.New("min が max より大きくなっています")
+	}
+	if f.Label == "" {
+		f.Label = f.ID
+	}
+
+	spec := formFieldSpec{
+		id:          f.ID,
+		kind:        kind,
+		label:       f.Label,
+		placeholder: f.Placeholder,
+		required:    f.Required,
+		maxLength:   f.MaxLength,
+		lengthUnit:  unit,
+		options:     f.Options,
+		min:         f.Min,
+		max:         f.Max,
+	}
+	if list, ok := f.Default.([]any); ok {
+		// リストの既定値は文字列に連結せずに渡す（選択肢にカンマを含む場合があるため）
+		if kind != fieldMultiSelect {
+			return formFieldSpec{}, errors.New("default にリストを指定できるのは multiselect だけです")
+		}
+		spec.defaultOpts = formSpecValues(list)
+	} else {
+		spec.defaultVal = formSpecDefault(f.Default)
+	}
+	if f.MinLength > 0 {
+		spec.validators = append(spec.validators, minLength(f.MinLength))
+	}
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return formFieldSpec{}, fmt.Errorf("pattern が正しくありません: %w", err)
+		}
+		message := f.PatternMessage
+		if message == "" {
+			message = fmt.Sprintf("%sの形式が正しくありません", f.Label)
+		}
+		spec.validators = append(spec.validators, matchPattern(f.Pattern, message))
+	}
+	if f.Available != "" {
+		spec.async = usernameAvailable(f.Available, newFormCheckClient())
+	}
+	return spec, nil
+}
+
+// 条件の値を文字列の一覧に変換（リスト以外は1つの値）
+func formSpecValues(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = formSpecDefault(e)
+		}
+		return values
+	}
+	return []string{formSpecDefault(v)}
+}
+
+// 既定値を formField.setValue が解釈できる文字列に変換（リスト以外の値）
+func formSpecDefault(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return formatFormNumber(v)
+	case time.Time:
+		// YAMLでは引用符のない日付がタイムスタンプとして読まれる
+		return v.Format(formDateLayout)
+	}
+	return fmt.Sprint(v)
+}
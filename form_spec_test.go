@@ -0,0 +1,170 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const surveySpecYAML = `
+id: survey
+title: 開発者アンケート
+submit: 回答する
+fields:
+  - id: name
+    label: 名前
+    required: true
+    min_length: 2
+  - id: handle
+    label: ハンドル名
+    pattern: "^[a-z0-9_]+$"
+    pattern_message: 英小文字・数字・_ で入力してください
+  - id: team
+    type: select
+    label: チーム
+    options: [開発, 運用, 営業]
+    default: 運用
+  - id: langs
+    type: multiselect
+    options: [Go, Rust, TypeScript, "C, C++"]
+    default: [Go, "C, C++"]
+  - id: age
+    type: number
+    min: 0
+    max: 150
+    default: 30
+  - id: joined
+    type: date
+    default: 2024-04-01
+  - id: agree
+    type: checkbox
+    default: true
+`
+
+// 定義ファイルをテスト用のディレクトリに書き出す
+func writeFormSpec(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFormSchema(t *testing.T) {
+	t.Run("YAMLの定義", func(t *testing.T) {
+		schema, err := loadFormSchema(writeFormSpec(t, "survey.yaml", surveySpecYAML))
+		if err != nil {
+			t.Fatalf("読み込めるべき: %v", err)
+		}
+		if schema.id != "survey" || schema.title != "開発者アンケート" || schema.submitLabel != "回答する" || len(schema.fields) != 7 {
+			t.Fatalf("フォームの定義が読み込まれるべき、実際: %+v", schema)
+		}
+		if schema.fields[3].label != "langs" || schema.fields[3].kind != fieldMultiSelect {
+			t.Errorf("表示名の省略時はIDを使うべき、実際: %+v", schema.fields[3])
+		}
+
+		name, handle, age := schema.fields[0], schema.fields[1], schema.fields[4]
+		if name.validate("山") == nil || name.validate("山田") != nil {
+			t.Error("min_length で検証されるべき")
+		}
+		if err := handle.validate("Taro"); err == nil || err.Error() != "英小文字・数字・_ で入力してください" {
+			t.Errorf("pattern のエラーメッセージを使うべき、実際: %v", err)
+		}
+		if age.validate("151") == nil || age.validate("42") != nil {
+			t.Error("min/max で検証されるべき")
+		}
+	})
+
+	t.Run("サンプルの定義", func(t *testing.T) {
+		for _, name := range []string{"survey.yaml", "onboarding.yaml"} {
+			if _, err := loadFormSchema(filepath.Join("examples", name)); err != nil {
+				t.Errorf("examples/%s を読み込めるべき: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("既定値を入力欄に設定", func(t *testing.T) {
+		schema, _ := loadFormSchema(writeFormSpec(t, "survey.yml", surveySpecYAML))
+		data := NewFormModelWithSchema(schema).getFormData()
+
+		if data["team"] != "運用" || data["age"] != 30.0 || data["joined"] != "2024-04-01" || data["agree"] != true {
+			t.Errorf("既定値が設定されるべき、実際: %v", data)
+		}
+		if langs := data["langs"].([]string); strings.Join(langs, "|") != "Go|C, C++" {
+			t.Errorf("カンマを含む選択肢も既定値に設定されるべき、実際: %v", langs)
+		}
+	})
+
+	t.Run("JSONの定義", func(t *testing.T) {
+		path := writeFormSpec(t, "signup.json", `{
+			"fields": [
+				{"id": "user", "label": "ユーザー名", "required": true, "available": "http://localhost"},
+				{"id": "email", "type": "email", "default": "taro@example.com"}
+			]
+		}`)
+		schema, err := loadFormSchema(path)
+		if err != nil {
+			t.Fatalf("読み込めるべき: %v", err)
+		}
+		if schema.id != "signup" || schema.title != "📝 signup" {
+			t.Errorf("IDの省略時はファイル名を使うべき、実際: %q %q", schema.id, schema.title)
+		}
+		if schema.fields[0].async == nil || schema.fields[1].defaultVal != "taro@example.com" {
+			t.Errorf("非同期の検証と既定値が設定されるべき、実際: %+v", schema.fields)
+		}
+	})
+
+	t.Run("長さの単位", func(t *testing.T) {
+		schema, err := loadFormSchema(writeFormSpec(t, "name.yaml", "fields:\n  - id: name\n    max_length: 20\n    length_unit: cells"))
+		if err != nil || schema.fields[0].lengthUnit != lengthCells || schema.fields[0].charLimit() != 20 {
+			t.Errorf("表示幅で制限するべき、実際: %+v (%v)", schema.fields, err)
+		}
+	})
+
+	t.Run("定義のエラー", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			content string
+			want    string
+		}{
+			{"フィールドなし", `id: empty`, "フィールドがありません"},
+			{"IDなし", "fields:\n  - label: 名前", "1番目のフィールドに id がありません"},
+			{"IDの重複", "fields:\n  - id: a\n  - id: a", "「a」が重複しています"},
+			{"不明な種類", "fields:\n  - id: a\n    type: color", `種類 "color" は不明です`},
+			{"選択肢なし", "fields:\n  - id: a\n    type: select", "options がありません"},
+			{"範囲の逆転", "fields:\n  - id: a\n    type: number\n    min: 10\n    max: 1", "min が max より大きく"},
+			{"正規表現の誤り", "fields:\n  - id: a\n    pattern: \"[\"", "pattern が正しくありません"},
+			{"不明なキー", "fields:\n  - id: a\n    requird: true", "パースエラー"},
+			{"不明な長さの単位", "fields:\n  - id: a\n    length_unit: bytes", `length_unit "bytes" は不明です`},
+			{"複数選択以外のリストの既定値", "fields:\n  - id: a\n    default: [x, y]", "multiselect だけです"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := loadFormSchema(writeFormSpec(t, "spec.yaml", tt.content))
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("エラーに %q を含むべき、実際: %v", tt.want, err)
+				}
+			})
+		}
+	})
+}
+
+func TestFormQuitOnSubmit(t *testing.T) {
+	schema, _ := loadFormSchema(writeFormSpec(t, "survey.yaml", surveySpecYAML))
+	m := NewFormModelWithSchema(schema)
+	m.quitOnSubmit = true
+	m.fields[0].input.SetValue("山田太郎")
+
+	newModel, cmd := m.focus(m.submitIndex()).Update(formKeyEnter)
+	m = newModel.(formModel)
+	if !m.submitted || cmd == nil {
+		t.Fatalf("送信が完了するべき: %s", m.errorMessage)
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("送信したら終了するべき")
+	}
+}
@@ -7,22 +7,29 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// ユーザー登録フォームのフォーカス位置
+const (
+	regNameField = iota
+	regEmailField
+	regSubmitButton
+)
+
 func TestFormModel(t *testing.T) {
 	t.Run("初期状態", func(t *testing.T) {
 		m := NewFormModel()
-		if m.focusIndex != nameInput {
+		if m.focusIndex != regNameField {
 			t.Errorf("初期フォーカスは名前フィールドであるべき、実際: %d", m.focusIndex)
 		}
 		if m.state != formInput {
 			t.Error("初期状態はformInputであるべき")
 		}
-		if len(m.inputs) != 2 {
-			t.Errorf("入力フィールドは2つであるべき、実際: %d", len(m.inputs))
+		if len(m.fields) != 2 {
+			t.Errorf("入力フィールドは2つであるべき、実際: %d", len(m.fields))
 		}
-		if !m.inputs[nameInput].Focused() {
+		if !m.fields[regNameField].input.Focused() {
 			t.Error("名前フィールドにフォーカスがあるべき")
 		}
-		if m.inputs[emailInput].Focused() {
+		if m.fields[regEmailField].input.Focused() {
 			t.Error("メールフィールドにフォーカスがないべき")
 		}
 	})
@@ -42,44 +49,44 @@ func TestFormModel(t *testing.T) {
 		// 名前 → メール
 		newModel, _ := m.Update(msg)
 		updatedModel := newModel.(formModel)
-		if updatedModel.focusIndex != emailInput {
+		if updatedModel.focusIndex != regEmailField {
 			t.Errorf("Tabキー後のフォーカスはメールフィールドであるべき、実際: %d", updatedModel.focusIndex)
 		}
-		if !updatedModel.inputs[emailInput].Focused() {
+		if !updatedModel.fields[regEmailField].input.Focused() {
 			t.Error("メールフィールドにフォーカスがあるべき")
 		}
 
 		// メール → 送信ボタン
 		newModel, _ = updatedModel.Update(msg)
 		updatedModel = newModel.(formModel)
-		if updatedModel.focusIndex != submitButton {
+		if updatedModel.focusIndex != regSubmitButton {
 			t.Errorf("Tabキー後のフォーカスは送信ボタンであるべき、実際: %d", updatedModel.focusIndex)
 		}
 
 		// 送信ボタン → 名前（ループ）
 		newModel, _ = updatedModel.Update(msg)
 		updatedModel = newModel.(formModel)
-		if updatedModel.focusIndex != nameInput {
+		if updatedModel.focusIndex != regNameField {
 			t.Errorf("Tabキー後のフォーカスは名前フィールドに戻るべき、実際: %d", updatedModel.focusIndex)
 		}
 	})
 
 	t.Run("Shift+Tabキーで前のフィールドへ移動", func(t *testing.T) {
 		m := NewFormModel()
-		m.focusIndex = emailInput
+		m.focusIndex = regEmailField
 		msg := tea.KeyMsg{Type: tea.KeyShiftTab}
 
 		// メール → 名前
 		newModel, _ := m.Update(msg)
 		updatedModel := newModel.(formModel)
-		if updatedModel.focusIndex != nameInput {
+		if updatedModel.focusIndex != regNameField {
 			t.Errorf("Shift+Tab後のフォーカスは名前フィールドであるべき、実際: %d", updatedModel.focusIndex)
 		}
 
 		// 名前 → 送信ボタン（ループ）
 		newModel, _ = updatedModel.Update(msg)
 		updatedModel = newModel.(formModel)
-		if updatedModel.focusIndex != submitButton {
+		if updatedModel.focusIndex != regSubmitButton {
 			t.Errorf("Shift+Tab後のフォーカスは送信ボタンであるべき、実際: %d", updatedModel.focusIndex)
 		}
 	})
@@ -90,19 +97,19 @@ func TestFormModel(t *testing.T) {
 
 		newModel, _ := m.Update(msg)
 		updatedModel := newModel.(formModel)
-		if updatedModel.focusIndex != emailInput {
+		if updatedModel.focusIndex != regEmailField {
 			t.Errorf("↓キー後のフォーカスはメールフィールドであるべき、実際: %d", updatedModel.focusIndex)
 		}
 	})
 
 	t.Run("↑キーで前のフィールドへ移動", func(t *testing.T) {
 		m := NewFormModel()
-		m.focusIndex = emailInput
+		m.focusIndex = regEmailField
 		msg := tea.KeyMsg{Type: tea.KeyUp}
 
 		newModel, _ := m.Update(msg)
 		updatedModel := newModel.(formModel)
-		if updatedModel.focusIndex != nameInput {
+		if updatedModel.focusIndex != regNameField {
 			t.Errorf("↑キー後のフォーカスは名前フィールドであるべき、実際: %d", updatedModel.focusIndex)
 		}
 	})
@@ -110,9 +117,9 @@ func TestFormModel(t *testing.T) {
 	t.Run("バリデーション - 名前が空", func(t *testing.T) {
 		m := NewFormModel()
 		// 名前フィールドは空のまま
-		m.inputs[emailInput].SetValue("test@example.com")
+		m.fields[regEmailField].input.SetValue("test@example.com")
 
-		err := m.validate()
+		err := checkFormFields(m)
 		if err == nil {
 			t.Error("名前が空の場合はエラーが返されるべき")
 		}
@@ -123,10 +130,10 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("バリデーション - メールが空", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("山田太郎")
+		m.fields[regNameField].input.SetValue("山田太郎")
 		// メールフィールドは空のまま
 
-		err := m.validate()
+		err := checkFormFields(m)
 		if err == nil {
 			t.Error("メールが空の場合はエラーが返されるべき")
 		}
@@ -137,7 +144,7 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("バリデーション - 不正なメール形式", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("山田太郎")
+		m.fields[regNameField].input.SetValue("山田太郎")
 		
 		// 不正なメール形式のテストケース
 		invalidEmails := []string{
@@ -149,8 +156,8 @@ func TestFormModel(t *testing.T) {
 		}
 
 		for _, email := range invalidEmails {
-			m.inputs[emailInput].SetValue(email)
-			err := m.validate()
+			m.fields[regEmailField].input.SetValue(email)
+			err := checkFormFields(m)
 			if err == nil {
 				t.Errorf("メール形式が不正な場合はエラーが返されるべき: %s", email)
 			}
@@ -162,7 +169,7 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("バリデーション - 正常な入力", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("山田太郎")
+		m.fields[regNameField].input.SetValue("山田太郎")
 		
 		// 正常なメール形式のテストケース
 		validEmails := []string{
@@ -173,8 +180,8 @@ func TestFormModel(t *testing.T) {
 		}
 
 		for _, email := range validEmails {
-			m.inputs[emailInput].SetValue(email)
-			err := m.validate()
+			m.fields[regEmailField].input.SetValue(email)
+			err := checkFormFields(m)
 			if err != nil {
 				t.Errorf("正常な入力でエラーが返されるべきでない: %s, エラー: %v", email, err)
 			}
@@ -183,7 +190,7 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("送信処理 - バリデーションエラー", func(t *testing.T) {
 		m := NewFormModel()
-		m.focusIndex = submitButton
+		m.focusIndex = regSubmitButton
 		// 入力なしで送信
 		msg := tea.KeyMsg{Type: tea.KeyEnter}
 
@@ -203,9 +210,9 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("送信処理 - 正常", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("山田太郎")
-		m.inputs[emailInput].SetValue("taro@example.com")
-		m.focusIndex = submitButton
+		m.fields[regNameField].input.SetValue("山田太郎")
+		m.fields[regEmailField].input.SetValue("taro@example.com")
+		m.focusIndex = regSubmitButton
 		msg := tea.KeyMsg{Type: tea.KeyEnter}
 
 		newModel, _ := m.Update(msg)
@@ -224,15 +231,15 @@ func TestFormModel(t *testing.T) {
 
 	t.Run("フォームデータ取得", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("  山田太郎  ")  // 前後に空白
-		m.inputs[emailInput].SetValue("  taro@example.com  ")
+		m.fields[regNameField].input.SetValue("  山田太郎  ")  // 前後に空白
+		m.fields[regEmailField].input.SetValue("  taro@example.com  ")
 
 		data := m.getFormData()
-		if data.name != "山田太郎" {
-			t.Errorf("名前の空白がトリムされるべき、実際: '%s'", data.name)
+		if data["name"] != "山田太郎" {
+			t.Errorf("名前の空白がトリムされるべき、実際: '%s'", data["name"])
 		}
-		if data.email != "taro@example.com" {
-			t.Errorf("メールの空白がトリムされるべき、実際: '%s'", data.email)
+		if data["email"] != "taro@example.com" {
+			t.Errorf("メールの空白がトリムされるべき、実際: '%s'", data["email"])
 		}
 	})
 
@@ -243,7 +250,7 @@ func TestFormModel(t *testing.T) {
 		// 名前フィールドでEnter
 		newModel, _ := m.Update(msg)
 		updatedModel := newModel.(formModel)
-		if updatedModel.focusIndex != emailInput {
+		if updatedModel.focusIndex != regEmailField {
 			t.Error("名前フィールドでEnterを押すとメールフィールドに移動すべき")
 		}
 	})
@@ -320,8 +327,8 @@ func TestFormModelView(t *testing.T) {
 
 	t.Run("送信完了画面の表示", func(t *testing.T) {
 		m := NewFormModel()
-		m.inputs[nameInput].SetValue("山田太郎")
-		m.inputs[emailInput].SetValue("taro@example.com")
+		m.fields[regNameField].input.SetValue("山田太郎")
+		m.fields[regEmailField].input.SetValue("taro@example.com")
 		m.state = formSubmitted
 		view := m.View()
 
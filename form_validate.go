@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// フィールドの検証はフォーカスが外れた時に行い、エラーはフィールドの下に表示する。
+// 非同期の検証（サーバーへの問い合わせなど）は同期の検証を通った値に対してだけ実行する。
+
+// 非同期の検証（コマンドの結果のメッセージがエラー、nilの場合は問題なし）
+type formAsyncValidator func(value string) tea.Cmd
+
+// 非同期の検証が完了した時のメッセージ
+type formValidatedMsg struct {
+	field int    // フィールドの位置
+	seq   int    // 検証の通し番号（古い検証の結果は無視する）
+	value string // 検証した値
+	err   error
+}
+
+// 最小文字数の検証（見た目の文字数で数える）
+func minLength(n int) formValidator {
+	return func(value string) error {
+		if lengthGraphemes.measure(value) < n {
+			return fmt.Errorf("%d文字以上で入力してください", n)
+		}
+		return nil
+	}
+}
+
+// 正規表現の検証（一致しない場合は message のエラー）
+func matchPattern(pattern, message string) formValidator {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// ユーザー名が使われていないかを baseURL のサーバーに問い合わせる非同期の検証。
+// GET {baseURL}/users/{name} が404なら使用可能、200なら使用済み。
+func usernameAvailable(baseURL string, client *http.Client) formAsyncValidator {
+	return func(value string) tea.Cmd {
+		return func() tea.Msg {
+			resp, err := client.Get(strings.TrimSuffix(baseURL, "/") + "/users/" + url.PathEscape(value))
+			if err != nil {
+				return fmt.Errorf("確認できませんでした: %w", err)
+			}
+			defer resp.Body.Close()
+
+			switch resp.StatusCode {
+			case http.StatusNotFound:
+				return nil
+			case http.StatusOK:
+				return fmt.Errorf("「%s」は既に使われています", value)
+			}
+			return fmt.Errorf("確認できませんでした: ステータスコード %d", resp.StatusCode)
+		}
+	}
+}
+
+// 非同期の検証に使うHTTPクライアント
+func newFormCheckClient() *http.Client {
+	return &http.Client{Timeout: constants.FormCheckTimeout}
+}
+
+// 非同期の検証のコマンドを、結果をフィールドに対応付けるメッセージに包む
+func runFormAsyncValidator(v formAsyncValidator, field, seq int, value string) tea.Cmd {
+	cmd := v(value)
+	return func() tea.Msg {
+		msg := formValidatedMsg{field: field, seq: seq, value: value}
+		if cmd == nil {
+			return msg
+		}
+		switch result := cmd().(type) {
+		case nil:
+		case error:
+			msg.err = result
+		default:
+			msg.err = fmt.Errorf("不明な検証結果です: %v", result)
+		}
+		return msg
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 使用済みのユーザー名だけ200を返すスタブサーバー
+func newUsernameStubServer(t *testing.T, taken ...string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/users/")
+		for _, n := range taken {
+			if n == name {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ユーザー名を確認するテスト用のフォーム
+func newSignupForm(baseURL string) formModel {
+	return NewFormModelWithSchema(formSchema{
+		id:    "signup",
+		title: "サインアップ",
+		fields: []formFieldSpec{
+			{
+				id:        "username",
+				label:     "ユーザー名",
+				required:  true,
+				maxLength: 12,
+				validators: []formValidator{
+					minLength(3),
+					matchPattern(`^[a-z0-9-]+$`, "英小文字・数字・ハイフンで入力してください"),
+				},
+				async: usernameAvailable(baseURL, newFormCheckClient()),
+			},
+			{id: "email", kind: fieldEmail, label: "メールアドレス"},
+		},
+	})
+}
+
+// コマンドを実行して返ってきた検証結果のメッセージでフォームを更新
+func runFormChecks(t *testing.T, m formModel, cmd tea.Cmd) formModel {
+	t.Helper()
+	var msgs []tea.Msg
+	var collect func(cmd tea.Cmd)
+	collect = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				collect(c)
+			}
+		case formValidatedMsg:
+			msgs = append(msgs, msg)
+		}
+	}
+	collect(cmd)
+	for _, msg := range msgs {
+		newModel, _ := m.Update(msg)
+		m = newModel.(formModel)
+	}
+	return m
+}
+
+func typeFormText(m formModel, s string) formModel {
+	for _, r := range s {
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestFormValidators(t *testing.T) {
+	t.Run("文字数と正規表現", func(t *testing.T) {
+		spec := formFieldSpec{label: "ID", maxLength: 5, validators: []formValidator{minLength(3), matchPattern(`^\d+$`, "数字のみ")}}
+
+		for value, want := range map[string]string{"12": "3文字以上", "123456": "5文字以内", "12a": "数字のみ", "1234": ""} {
+			err := spec.validate(value)
+			if want == "" && err != nil || want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+				t.Errorf("validate(%q) = %v、期待値: %q", value, err, want)
+			}
+		}
+	})
+
+	t.Run("ユーザー名の確認", func(t *testing.T) {
+		server := newUsernameStubServer(t, "taken")
+		check := usernameAvailable(server.URL, newFormCheckClient())
+
+		if msg := check("free")(); msg != nil {
+			t.Errorf("未使用のユーザー名は問題なしであるべき、実際: %v", msg)
+		}
+		if err, ok := check("taken")().(error); !ok || !strings.Contains(err.Error(), "既に使われています") {
+			t.Errorf("使用済みのユーザー名はエラーになるべき、実際: %v", err)
+		}
+	})
+}
+
+func TestFormInlineValidation(t *testing.T) {
+	t.Run("フィールドを離れた時にエラーを表示", func(t *testing.T) {
+		m := NewFormModel()
+		m = sendFormKeys(m, formKeyTab)
+
+		if m.fields[regNameField].err == nil {
+			t.Fatal("空の必須フィールドを離れるとエラーになるべき")
+		}
+		if view := m.View(); !strings.Contains(view, "❌ 名前を入力してください") {
+			t.Errorf("フィールドの下にエラーが表示されるべき:\n%s", view)
+		}
+
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyShiftTab})
+		m = typeFormText(m, "山")
+		if m.fields[regNameField].err != nil {
+			t.Error("値を変更するとエラーは消えるべき")
+		}
+	})
+
+	t.Run("送信時に全てのフィールドのエラーを表示", func(t *testing.T) {
+		m := NewFormModel()
+		m.fields[regEmailField].input.SetValue("invalid")
+		m = sendFormKeys(m.focus(regSubmitButton), formKeyEnter)
+
+		if m.state != formInput || !strings.Contains(m.errorMessage, "2件") {
+			t.Errorf("エラーの件数が表示されるべき、実際: %q", m.errorMessage)
+		}
+		view := m.View()
+		for _, want := range []string{"名前を入力してください", "正しいメールアドレスの形式"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("ビューに「%s」が含まれているべき:\n%s", want, view)
+			}
+		}
+	})
+
+	t.Run("非同期の検証中はスピナーを表示", func(t *testing.T) {
+		server := newUsernameStubServer(t, "taken")
+		m := typeFormText(newSignupForm(server.URL), "taken")
+
+		newModel, cmd := m.Update(formKeyTab)
+		m = newModel.(formModel)
+		if !m.fields[0].checking || !strings.Contains(m.View(), "確認中") {
+			t.Fatalf("確認中の表示になるべき:\n%s", m.View())
+		}
+
+		m = runFormChecks(t, m, cmd)
+		if m.fields[0].checking || m.fields[0].err == nil {
+			t.Errorf("使用済みのエラーになるべき、実際: %v", m.fields[0].err)
+		}
+	})
+
+	t.Run("同期の検証を通らなければ問い合わせない", func(t *testing.T) {
+		m := typeFormText(newSignupForm("http://127.0.0.1:0"), "AB")
+
+		newModel, _ := m.Update(formKeyTab)
+		m = newModel.(formModel)
+		if m.fields[0].checking || m.fields[0].err == nil || !strings.Contains(m.fields[0].err.Error(), "3文字以上") {
+			t.Errorf("同期の検証のエラーになるべき、実際: %v", m.fields[0].err)
+		}
+	})
+
+	t.Run("変更前の値の結果は無視", func(t *testing.T) {
+		server := newUsernameStubServer(t, "taken")
+		m := typeFormText(newSignupForm(server.URL), "taken")
+		newModel, cmd := m.Update(formKeyTab)
+		m = newModel.(formModel)
+
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyShiftTab})
+		m = typeFormText(m, "2")
+		m = runFormChecks(t, m, cmd)
+		if m.fields[0].err != nil {
+			t.Errorf("古い値の検証結果は反映されないべき、実際: %v", m.fields[0].err)
+		}
+	})
+
+	t.Run("確認が終わってから送信", func(t *testing.T) {
+		server := newUsernameStubServer(t, "taken")
+		m := typeFormText(newSignupForm(server.URL), "free-name")
+		m = m.focus(m.submitIndex())
+
+		newModel, cmd := m.Update(formKeyEnter)
+		m = newModel.(formModel)
+		if m.state != formInput || !m.pendingNext {
+			t.Fatal("確認中は送信を待つべき")
+		}
+
+		m = runFormChecks(t, m, cmd)
+		if m.state != formSubmitted {
+			t.Errorf("確認が終わったら送信されるべき、エラー: %q %v", m.errorMessage, m.fields[0].err)
+		}
+	})
+
+	t.Run("不明な検証結果はエラー", func(t *testing.T) {
+		v := func(string) tea.Cmd { return func() tea.Msg { return "ok" } }
+		msg := runFormAsyncValidator(v, 0, 1, "a")().(formValidatedMsg)
+		if msg.err == nil {
+			t.Error("エラー以外の結果はエラーになるべき")
+		}
+		msg = runFormAsyncValidator(func(string) tea.Cmd { return func() tea.Msg { return errors.New("x") } }, 0, 1, "a")().(formValidatedMsg)
+		if msg.err == nil || msg.err.Error() != "x" {
+			t.Errorf("エラーはそのまま伝わるべき、実際: %v", msg.err)
+		}
+	})
+}
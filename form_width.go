@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+// 日本語などの全角文字・絵文字・結合文字を含む入力の長さと表示幅。
+// 入力の長さは見た目の文字数（書記素クラスタ）か表示幅（セル数）で数え、
+// IMEの確定や貼り付けでまとめて届いた文字は上限に収まる分だけ入力する。
+
+// 入力の長さの単位
+type formLengthUnit int
+
+const (
+	lengthGraphemes formLengthUnit = iota // 見た目の文字数（結合文字・絵文字のシーケンスも1文字）
+	lengthCells                           // 表示幅（全角文字・絵文字は2、結合文字は0）
+)
+
+// ラベルの列の最小幅
+const formLabelMinWidth = 15
+
+// 文字列の長さ
+func (u formLengthUnit) measure(s string) int {
+	if u == lengthCells {
+		return uniseg.StringWidth(s)
+	}
+	return uniseg.GraphemeClusterCount(s)
+}
+
+// 長さが limit 以下になる先頭部分（文字の途中では切らない）
+func (u formLengthUnit) truncate(s string, limit int) string {
+	g := uniseg.NewGraphemes(s)
+	n, end := 0, 0
+	for g.Next() {
+		w := 1
+		if u == lengthCells {
+			w = g.Width()
+		}
+		if n+w > limit {
+			break
+		}
+		n += w
+		_, end = g.Positions()
+	}
+	return s[:end]
+}
+
+// 上限の説明（エラーメッセージに使う）
+func (u formLengthUnit) limitText(n int) string {
+	if u == lengthCells {
+		return fmt.Sprintf("半角%d文字（全角%d文字）以内", n, n/2)
+	}
+	return fmt.Sprintf("%d文字以内", n)
+}
+
+// まとめて届いた文字（IMEの確定・貼り付け）を残りの長さに収まる分だけにする
+func (f formField) fitInput(msg tea.Msg) tea.Msg {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || key.Type != tea.KeyRunes || !f.spec.kind.textual() {
+		return msg
+	}
+	room := max(f.spec.charLimit()-f.spec.lengthUnit.measure(f.value()), 0)
+	key.Runes = []rune(f.spec.lengthUnit.truncate(string(key.Runes), room))
+	return key
+}
+
+// 上限を超えて長くなった場合は入力前の値に戻す（Space・クリップボードからの貼り付けなど fitInput を通らない入力）
+func (f formField) revertOverflow(before string, pos int) formField {
+	unit, limit := f.spec.lengthUnit, f.spec.charLimit()
+	after := unit.measure(f.value())
+	if after <= limit || after <= unit.measure(before) {
+		return f
+	}
+	if f.spec.kind == fieldTextarea {
+		// 複数行テキストはカーソルの位置を戻せないので末尾に置く
+		f.area.SetValue(before)
+		return f
+	}
+	f.input.SetValue(before)
+	f.input.SetCursor(pos)
+	return f
+}
+
+// 入力中の長さの表示（例: 12/50）
+func (f formField) lengthView() string {
+	if !f.spec.kind.textual() || f.spec.kind == fieldNumber {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", f.spec.lengthUnit.measure(f.value()), f.spec.charLimit())
+}
+
+// ラベルの列の幅（全角文字を2として、最も長いラベルに合わせる）
+func (m formModel) labelWidth() int {
+	width := formLabelMinWidth
+	for _, f := range m.schema.fields {
+		width = max(width, lipgloss.Width(f.label+":")+1)
+	}
+	return width
+}
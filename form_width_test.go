@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 1つのフィールドだけのフォーム
+func newSingleFieldForm(spec formFieldSpec) formModel {
+	return NewFormModelWithSchema(formSchema{id: "single", fields: []formFieldSpec{spec}})
+}
+
+// IMEの確定のように複数の文字をまとめて入力
+func commitFormText(m formModel, s string) formModel {
+	return sendFormKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+}
+
+func TestFormLengthUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		graphemes int
+		cells     int
+	}{
+		{"ASCII", "Taro", 4, 4},
+		{"全角文字", "山田太郎", 4, 8},
+		{"半角カナ", "ﾔﾏﾀ", 3, 3},
+		{"結合文字", "e\u0301", 1, 1},
+		{"絵文字のシーケンス", "👨‍👩‍👧", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if n := lengthGraphemes.measure(tt.s); n != tt.graphemes {
+				t.Errorf("文字数は %d であるべき、実際: %d", tt.graphemes, n)
+			}
+			if n := lengthCells.measure(tt.s); n != tt.cells {
+				t.Errorf("表示幅は %d であるべき、実際: %d", tt.cells, n)
+			}
+		})
+	}
+
+	t.Run("文字の途中で切らない", func(t *testing.T) {
+		if s := lengthCells.truncate("山田太郎", 5); s != "山田" {
+			t.Errorf("全角文字の途中で切らないべき、実際: %q", s)
+		}
+		if s := lengthGraphemes.truncate("ae\u0301b", 2); s != "ae\u0301" {
+			t.Errorf("結合文字を切り離さないべき、実際: %q", s)
+		}
+		if s := lengthCells.truncate("a👨‍👩‍👧", 2); s != "a" {
+			t.Errorf("絵文字のシーケンスを切り離さないべき、実際: %q", s)
+		}
+	})
+}
+
+func TestFormWideInput(t *testing.T) {
+	t.Run("IMEの確定は表示幅に収まる分だけ入力", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "name", kind: fieldText, label: "名前", maxLength: 5, lengthUnit: lengthCells})
+		m = commitFormText(m, "山田太郎")
+		if v := m.fields[0].value(); v != "山田" {
+			t.Errorf("半角5文字分に収まる分だけ入力されるべき、実際: %q", v)
+		}
+		m = commitFormText(m, "a")
+		if v := m.fields[0].value(); v != "山田a" {
+			t.Errorf("残りの幅に半角文字は入力できるべき、実際: %q", v)
+		}
+	})
+
+	t.Run("文字数は見た目の文字で数える", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "name", kind: fieldText, label: "名前", maxLength: 3})
+		m = commitFormText(m, "👨‍👩‍👧ge\u0301x")
+		if v := m.fields[0].value(); v != "👨‍👩‍👧ge\u0301" {
+			t.Errorf("3文字まで入力されるべき、実際: %q", v)
+		}
+	})
+
+	t.Run("上限を超えるSpaceは入力しない", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "name", kind: fieldText, label: "名前", maxLength: 4, lengthUnit: lengthCells})
+		m = commitFormText(m, "山田")
+		m = sendFormKeys(m, formKeySpace)
+		if v := m.fields[0].value(); v != "山田" {
+			t.Errorf("上限を超えないべき、実際: %q", v)
+		}
+	})
+
+	t.Run("上限を超えた値も削除はできる", func(t *testing.T) {
+		spec := formFieldSpec{id: "name", kind: fieldText, label: "名前", maxLength: 4, lengthUnit: lengthCells, defaultVal: "山田太郎"}
+		m := newSingleFieldForm(spec)
+		if err := spec.validate(m.fields[0].value()); err == nil || !strings.Contains(err.Error(), "半角4文字（全角2文字）以内") {
+			t.Errorf("上限を超えた既定値はエラーになるべき、実際: %v", err)
+		}
+		m = sendFormKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+		if v := m.fields[0].value(); v != "山田太" {
+			t.Errorf("削除できるべき、実際: %q", v)
+		}
+	})
+
+	t.Run("複数行テキスト", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "note", kind: fieldTextarea, label: "メモ", maxLength: 6, lengthUnit: lengthCells})
+		m = commitFormText(m, "日本語テキスト")
+		if v := m.fields[0].value(); v != "日本語" {
+			t.Errorf("表示幅に収まる分だけ入力されるべき、実際: %q", v)
+		}
+	})
+
+	t.Run("入力中の長さを表示", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "name", kind: fieldText, label: "名前", maxLength: 20, lengthUnit: lengthCells})
+		m = commitFormText(m, "山田")
+		if !strings.Contains(m.View(), "4/20") {
+			t.Errorf("表示幅で数えた長さを表示するべき:\n%s", m.View())
+		}
+	})
+
+	t.Run("パスワードの伏せ字は見た目の文字数", func(t *testing.T) {
+		m := newSingleFieldForm(formFieldSpec{id: "pw", kind: fieldPassword, label: "パスワード"})
+		m = commitFormText(m, "ぱす👍🏽")
+		if d := m.fields[0].display(); d != "•••" {
+			t.Errorf("3文字分の伏せ字になるべき、実際: %q", d)
+		}
+	})
+}
+
+func TestFormLabelAlignment(t *testing.T) {
+	m := NewFormModelWithSchema(formSchema{id: "contact", fields: []formFieldSpec{
+		{id: "name", kind: fieldText, label: "名前"},
+		{id: "email2", kind: fieldText, label: "メールアドレス（確認用）"},
+	}})
+	if w := m.labelWidth(); w != lipgloss.Width("メールアドレス（確認用）:")+1 {
+		t.Errorf("最も長いラベルに合わせるべき、実際: %d", w)
+	}
+
+	m.fields[0].input.SetValue("山田太郎")
+	m.fields[1].input.SetValue("taro@example.com")
+	m = sendFormKeys(m.focus(m.submitIndex()), formKeyEnter)
+
+	// 値の列が揃っている
+	var columns []int
+	for _, line := range strings.Split(m.View(), "\n") {
+		for _, value := range []string{"山田太郎", "taro@example.com"} {
+			if i := strings.Index(line, value); i >= 0 {
+				columns = append(columns, lipgloss.Width(line[:i]))
+			}
+		}
+	}
+	if len(columns) != 2 || columns[0] != columns[1] {
+		t.Errorf("値の表示位置が揃うべき、実際: %v\n%s", columns, m.View())
+	}
+}
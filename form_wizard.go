@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 複数ページのフォーム（ウィザード）。
+// ページごとに検証してから次へ進み、最後に確認画面で全ての回答を見直してから送信する。
+// 表示条件のあるページは、前のページの回答が一致する場合だけ表示し、
+// 表示しないページのフィールドは検証・送信の対象にしない。
+
+// ページの表示条件
+type formCondition struct {
+	field  string   // 条件に使うフィールドのID（前のページのもの）
+	values []string // いずれかに一致すれば表示する
+}
+
+// ページの定義
+type formPage struct {
+	title  string
+	fields []string       // ページに含めるフィールドのID
+	when   *formCondition // 表示条件（nilの場合は常に表示）
+}
+
+// 表示条件に一致するか（複数選択はいずれかを選んでいれば一致、チェックボックスは true / false で比較）
+func (f formField) matches(values []string) bool {
+	switch f.spec.kind {
+	case fieldMultiSelect:
+		for _, o := range f.selectedOptions() {
+			if slices.Contains(values, o) {
+				return true
+			}
+		}
+		return false
+	case fieldCheckbox:
+		return slices.Contains(values, strconv.FormatBool(f.checked))
+	}
+	return slices.Contains(values, strings.TrimSpace(f.value()))
+}
+
+// 複数ページのフォームか
+func (m formModel) wizard() bool {
+	return len(m.schema.pages) > 0
+}
+
+// IDからフィールドの位置を探す（見つからない場合は-1）
+func (m formModel) fieldIndex(id string) int {
+	return slices.IndexFunc(m.schema.fields, func(f formFieldSpec) bool { return f.id == id })
+}
+
+// フィールドがあるページ（どのページにもない場合は-1）
+func (m formModel) fieldPage(i int) int {
+	id := m.schema.fields[i].id
+	return slices.IndexFunc(m.schema.pages, func(p formPage) bool { return slices.Contains(p.fields, id) })
+}
+
+// ページのフィールドの位置（1ページのフォームでは全てのフィールド）
+func (m formModel) pageFields(p int) []int {
+	if !m.wizard() {
+		indices := make([]int, len(m.fields))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	var indices []int
+	for _, id := range m.schema.pages[p].fields {
+		if i := m.fieldIndex(id); i >= 0 {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ページを表示するか（条件のフィールドがあるページが表示されない場合も表示しない）
+func (m formModel) pageVisible(p int) bool {
+	when := m.schema.pages[p].when
+	if when == nil {
+		return true
+	}
+	i := m.fieldIndex(when.field)
+	if i < 0 {
+		return false
+	}
+	// 条件には前のページのフィールドだけを使える
+	if fp := m.fieldPage(i); fp < 0 || fp >= p || !m.pageVisible(fp) {
+		return false
+	}
+	return m.fields[i].matches(when.values)
+}
+
+// 表示するページ
+func (m formModel) visiblePages() []int {
+	var pages []int
+	for p := range m.schema.pages {
+		if m.pageVisible(p) {
+			pages = append(pages, p)
+		}
+	}
+	return pages
+}
+
+// 検証・送信の対象のフィールドか
+func (m formModel) fieldVisible(i int) bool {
+	if !m.wizard() {
+		return true
+	}
+	p := m.fieldPage(i)
+	return p >= 0 && m.pageVisible(p)
+}
+
+// 検証・送信の対象のフィールドの位置
+func (m formModel) visibleFields() []int {
+	var indices []int
+	for i := range m.fields {
+		if m.fieldVisible(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// 表示中のページの次に表示するページ
+func (m formModel) followingPage() (int, bool) {
+	for p := m.page + 1; p < len(m.schema.pages); p++ {
+		if m.pageVisible(p) {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// ページを移動して先頭のフィールドにフォーカスを当てる
+func (m formModel) goToPage(p int) formModel {
+	m.page = p
+	m.errorMessage = ""
+	if fields := m.pageFields(p); len(fields) > 0 {
+		return m.focus(fields[0])
+	}
+	return m.focus(m.submitIndex())
+}
+
+// 表示中のページを検証して次のページへ（最後のページの次は確認画面）
+func (m formModel) nextPage() (formModel, tea.Cmd) {
+	m, cmd, ok := m.checkFields(m.pageFields(m.page))
+	if !ok {
+		return m, cmd
+	}
+	if p, ok := m.followingPage(); ok {
+		return m.goToPage(p).saveDraft(), nil
+	}
+	m.state = formReview
+	return m.focus(m.submitIndex()).saveDraft(), nil
+}
+
+// 前のページへ（入力した値はそのまま残す。確認画面からは最後のページへ）
+func (m formModel) prevPage() formModel {
+	m.pendingNext = false
+	if m.state == formReview {
+		m.state = formInput
+		return m.goToPage(m.page).saveDraft()
+	}
+	for p := m.page - 1; p >= 0; p-- {
+		if m.pageVisible(p) {
+			return m.goToPage(p).saveDraft()
+		}
+	}
+	return m
+}
+
+// 確認画面のキー処理（送信・前のページへ戻る・終了）
+func (m formModel) updateReview(msg tea.KeyMsg) (formModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return m.submit()
+	case tea.KeyPgUp, tea.KeyEsc, tea.KeyLeft:
+		return m.prevPage(), nil
+	case tea.KeyCtrlC:
+		return m.saveDraft(), tea.Quit
+	}
+	return m, nil
+}
+
+// 進み具合の表示（確認画面も1ステップとして数える）
+func (m formModel) progressView() string {
+	pages := m.visiblePages()
+	current := len(pages)
+	if m.state != formReview {
+		current = slices.Index(pages, m.page)
+	}
+	total := len(pages) + 1
+
+	title := "確認"
+	if m.state != formReview {
+		title = m.schema.pages[m.page].title
+	}
+	dots := strings.Repeat("●", current+1) + strings.Repeat("○", total-current-1)
+	return fmt.Sprintf("ステップ %d/%d  %s  %s", current+1, total, dots, title)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用のフィールドの位置
+const (
+	wizNameField = iota
+	wizRoleField
+	wizLangField
+	wizRegionField
+	wizAgreeField
+)
+
+var formKeyPgDown = tea.KeyMsg{Type: tea.KeyPgDown}
+var formKeyPgUp = tea.KeyMsg{Type: tea.KeyPgUp}
+
+// 職種によって2ページ目が変わるテスト用のウィザード
+func onboardingFormSchema() formSchema {
+	return formSchema{
+		id:    "onboarding",
+		title: "入社手続き",
+		fields: []formFieldSpec{
+			{id: "name", kind: fieldText, label: "名前", required: true},
+			{id: "role", kind: fieldSelect, label: "職種", required: true, options: []string{"開発", "営業"}},
+			{id: "lang", kind: fieldText, label: "使用言語", required: true},
+			{id: "region", kind: fieldSelect, label: "担当地域", required: true, options: []string{"東日本", "西日本"}},
+			{id: "agree", kind: fieldCheckbox, label: "規約", required: true},
+		},
+		pages: []formPage{
+			{title: "基本情報", fields: []string{"name", "role"}},
+			{title: "開発", fields: []string{"lang"}, when: &formCondition{field: "role", values: []string{"開発"}}},
+			{title: "営業", fields: []string{"region"}, when: &formCondition{field: "role", values: []string{"営業"}}},
+			{title: "規約", fields: []string{"agree"}},
+		},
+	}
+}
+
+// 1ページ目を入力したウィザード
+func newOnboardingForm(role string) formModel {
+	m := NewFormModelWithSchema(onboardingFormSchema())
+	m.fields[wizNameField].input.SetValue("山田太郎")
+	m.fields[wizRoleField] = m.fields[wizRoleField].setValue(role)
+	return m
+}
+
+func TestFormWizardNavigation(t *testing.T) {
+	t.Run("ページごとに検証してから進む", func(t *testing.T) {
+		m := NewFormModelWithSchema(onboardingFormSchema())
+		if !strings.Contains(m.View(), "ステップ 1/3") || strings.Contains(m.View(), "規約") {
+			t.Errorf("1ページ目だけを進み具合と一緒に表示するべき:\n%s", m.View())
+		}
+
+		m = sendFormKeys(m, formKeyPgDown)
+		if m.page != 0 || m.errorMessage != "2件の入力エラーがあります" {
+			t.Errorf("入力エラーがあれば進まないべき、実際: %d %q", m.page, m.errorMessage)
+		}
+	})
+
+	t.Run("回答によって次のページが変わる", func(t *testing.T) {
+		m := sendFormKeys(newOnboardingForm("開発"), formKeyPgDown)
+		if m.page != 1 || m.focusIndex != wizLangField || !strings.Contains(m.View(), "ステップ 2/4") {
+			t.Errorf("開発のページへ進むべき、実際: %d\n%s", m.page, m.View())
+		}
+
+		m = sendFormKeys(newOnboardingForm("営業"), formKeyPgDown)
+		if m.page != 2 || m.focusIndex != wizRegionField {
+			t.Errorf("営業のページへ進むべき、実際: %d", m.page)
+		}
+	})
+
+	t.Run("戻っても入力した値が残る", func(t *testing.T) {
+		m := sendFormKeys(newOnboardingForm("開発"), formKeyPgDown)
+		m = typeFormText(m, "Go")
+		m = sendFormKeys(m, formKeyPgUp)
+		if m.page != 0 || m.fields[wizNameField].value() != "山田太郎" {
+			t.Fatalf("前のページに戻るべき、実際: %d", m.page)
+		}
+
+		m = sendFormKeys(m, formKeyPgDown)
+		if m.fields[wizLangField].value() != "Go" {
+			t.Errorf("入力した値が残るべき、実際: %q", m.fields[wizLangField].value())
+		}
+	})
+
+	t.Run("ページ内のフィールドだけを移動", func(t *testing.T) {
+		m := newOnboardingForm("開発")
+		m = sendFormKeys(m, formKeyTab, formKeyTab)
+		if m.focusIndex != m.submitIndex() || !strings.Contains(m.View(), "[ 次へ ]") {
+			t.Errorf("ページの最後は次へのボタンになるべき、実際: %d", m.focusIndex)
+		}
+		m = sendFormKeys(m, formKeyTab)
+		if m.focusIndex != wizNameField {
+			t.Errorf("ボタンの次はページの先頭になるべき、実際: %d", m.focusIndex)
+		}
+	})
+}
+
+func TestFormWizardReview(t *testing.T) {
+	// 営業のページを経由して確認画面へ進む
+	m := sendFormKeys(newOnboardingForm("営業"), formKeyPgDown, formKeySpace, formKeyPgDown)
+	m = sendFormKeys(m, formKeySpace)
+	m = sendFormKeys(m, formKeyTab, formKeyEnter)
+	if m.state != formReview {
+		t.Fatalf("最後のページの次は確認画面になるべき: %q\n%s", m.errorMessage, m.View())
+	}
+
+	view := m.View()
+	for _, want := range []string{"ステップ 4/4", "基本情報", "山田太郎", "担当地域", "東日本", "規約"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("確認画面に %q を表示するべき:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "使用言語") {
+		t.Error("表示しないページの回答は確認画面に表示しないべき")
+	}
+
+	back := sendFormKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if back.state != formInput || back.page != 3 {
+		t.Errorf("Escで最後のページに戻るべき、実際: %d %d", back.state, back.page)
+	}
+
+	sink := &fakeFormSink{}
+	m.sink = sink
+	newModel, cmd := m.Update(formKeyEnter)
+	m = runFormSend(t, newModel.(formModel), cmd)
+	if m.state != formSubmitted || len(sink.sent) != 1 {
+		t.Fatalf("確認画面から送信するべき、実際: %d", m.state)
+	}
+	data := sink.sent[0].data
+	if data["region"] != "東日本" || data["agree"] != true {
+		t.Errorf("表示したページの回答を送信するべき、実際: %v", data)
+	}
+	if _, ok := data["lang"]; ok {
+		t.Error("表示しないページの回答は送信しないべき")
+	}
+}
+
+func TestLoadFormSchemaPages(t *testing.T) {
+	path := writeFormSpec(t, "onboarding.yaml", `
+pages:
+  - title: 基本情報
+    fields:
+      - id: role
+        type: select
+        options: [開発, 営業, 管理]
+  - title: 開発・管理
+    when: {field: role, equals: [開発, 管理]}
+    fields:
+      - id: lang
+  - fields:
+      - id: agree
+        type: checkbox
+`)
+	schema, err := loadFormSchema(path)
+	if err != nil {
+		t.Fatalf("読み込めるべき: %v", err)
+	}
+	if len(schema.fields) != 3 || len(schema.pages) != 3 {
+		t.Fatalf("ページのフィールドがまとめて読み込まれるべき、実際: %+v", schema)
+	}
+	if when := schema.pages[1].when; when == nil || when.field != "role" || strings.Join(when.values, ",") != "開発,管理" {
+		t.Errorf("表示条件が読み込まれるべき、実際: %+v", when)
+	}
+	if schema.pages[2].title != "ページ 3" || schema.pages[2].fields[0] != "agree" {
+		t.Errorf("タイトルの省略時は番号を使うべき、実際: %+v", schema.pages[2])
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"fieldsとpages", "fields:\n  - id: a\npages:\n  - fields:\n      - id: b", "同時に指定できません"},
+		{"空のページ", "pages:\n  - title: 空", "1番目のページにフィールドがありません"},
+		{"後のページの条件", "pages:\n  - when: {field: a, equals: x}\n    fields:\n      - id: a", "前のページにありません"},
+		{"条件の値なし", "pages:\n  - fields:\n      - id: a\n  - when: {field: a}\n    fields:\n      - id: b", "equals がありません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadFormSchema(writeFormSpec(t, "spec.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("エラーに %q を含むべき、実際: %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
@@ -11,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
 )
 
 // GitHubアプリの状態
@@ -58,10 +57,16 @@ type githubModel struct {
 	retryCount  int
 	maxRetries  int
 	lastRequest string
+	client      githubClient
 }
 
-// コンストラクタ
+// コンストラクタ（環境変数の設定でAPIを呼び出す）
 func NewGitHubModel() githubModel {
+	return NewGitHubModelWithClient(newGitHubClientFromEnv())
+}
+
+// NewGitHubModelWithClient - APIクライアントを指定してモデルを生成
+func NewGitHubModelWithClient(client githubClient) githubModel {
 	// テキスト入力の設定
 	ti := textinput.New()
 	ti.Placeholder = "例: octocat"
@@ -79,6 +84,7 @@ func NewGitHubModel() githubModel {
 		spinner:    sp,
 		state:      stateInput,
 		maxRetries: 3,
+		client:     client,
 	}
 }
 
@@ -102,7 +108,7 @@ func (m githubModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.retryCount = 0
 					return m, tea.Batch(
 						m.spinner.Tick,
-						fetchGitHubUser(username),
+						fetchGitHubUser(m.client, username),
 					)
 				}
 			case tea.KeyEsc, tea.KeyCtrlC:
@@ -118,7 +124,7 @@ func (m githubModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.retryCount++
 					return m, tea.Batch(
 						m.spinner.Tick,
-						fetchGitHubUser(m.lastRequest),
+						fetchGitHubUser(m.client, m.lastRequest),
 					)
 				}
 			case tea.KeyEsc:
@@ -175,47 +181,17 @@ func (m githubModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 // GitHubユーザー情報を取得
-func fetchGitHubUser(username string) tea.Cmd {
+func fetchGitHubUser(client githubClient, username string) tea.Cmd {
 	return func() tea.Msg {
-		// API呼び出し
-		url := fmt.Sprintf("https://api.github.com/users/%s", username)
-		
-		client := &http.Client{
-			Timeout: 10 * time.Second,
-		}
-		
-		req, err := http.NewRequest("GET", url, nil)
+		user, err := client.User(username)
 		if err != nil {
 			return apiResponse{err: err}
 		}
-		
-		// User-Agentヘッダーを設定（GitHub API要件）
-		req.Header.Set("User-Agent", "bubbletea-learning")
-		
-		resp, err := client.Do(req)
-		if err != nil {
-			return apiResponse{err: fmt.Errorf("ネットワークエラー: %w", err)}
-		}
-		defer resp.Body.Close()
-
-		// ステータスコードのチェック
-		if resp.StatusCode == 404 {
-			return apiResponse{err: fmt.Errorf("ユーザー '%s' が見つかりません", username)}
-		}
-		if resp.StatusCode != 200 {
-			return apiResponse{err: fmt.Errorf("APIエラー: ステータスコード %d", resp.StatusCode)}
-		}
-
-		// JSONパース
-		var user githubUser
-		if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-			return apiResponse{err: fmt.Errorf("JSONパースエラー: %w", err)}
-		}
 
 		// 少し遅延を入れて読み込み画面を見えやすくする（デモ用）
-		time.Sleep(500 * time.Millisecond)
+		time.Sleep(constants.DemoDelay)
 
-		return apiResponse{user: &user}
+		return apiResponse{user: user}
 	}
 }
 
@@ -259,6 +235,11 @@ func (m githubModel) View() string {
 		content = titleStyle.Render("🐙 GitHub ユーザー検索") + "\n\n"
 		content += "ユーザー名を入力してください:\n"
 		content += m.input.View() + "\n\n"
+		if m.client.authenticated() {
+			content += successStyle.Render("🔑 "+m.client.tokenEnv+" で認証") + "\n"
+		} else {
+			content += helpStyle.Render("未認証（1時間に60リクエストまで）") + "\n"
+		}
 		content += helpStyle.Render("Enter: 検索  Esc: 終了")
 
 	case stateLoading:
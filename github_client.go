@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// GitHub REST API のクライアント。
+// ベースURLを変えれば GitHub Enterprise（https://ghe.example.com/api/v3）やテスト用のサーバーにも向けられる。
+// トークンを指定すると認証付きで呼び出し、未認証のレート制限（1時間に60リクエスト）を避けられる。
+type githubClient struct {
+	baseURL  string
+	token    string // 空の場合は認証なし
+	tokenEnv string // トークンを読む環境変数（メッセージでの案内用）
+	http     *http.Client
+}
+
+// クライアントを生成（トークンを平文で送らないように、https 以外のURLでは認証しない）
+func newGitHubClient(baseURL string, timeout time.Duration, token string) githubClient {
+	if u, err := url.Parse(baseURL); err != nil || u.Scheme != "https" {
+		token = ""
+	}
+	return githubClient{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		token:    token,
+		tokenEnv: githubTokenEnv(baseURL),
+		http:     &http.Client{Timeout: timeout},
+	}
+}
+
+// ベースURLに送るトークンの環境変数
+// GITHUB_TOKEN は api.github.com にだけ送り、それ以外のホストには GITHUB_ENTERPRISE_TOKEN で明示したトークンを送る
+func githubTokenEnv(baseURL string) string {
+	if u, err := url.Parse(baseURL); err == nil && u.Host == "api.github.com" {
+		return "GITHUB_TOKEN"
+	}
+	return "GITHUB_ENTERPRISE_TOKEN"
+}
+
+// 既定のクライアント（GITHUB_API_URL があればそのURLに向け、ホストに応じたトークンがあれば認証）
+func newGitHubClientFromEnv() githubClient {
+	baseURL := os.Getenv("GITHUB_API_URL")
+	if baseURL == "" {
+		baseURL = constants.GitHubAPIBaseURL
+	}
+	return newGitHubClient(baseURL, constants.GitHubAPITimeout, os.Getenv(githubTokenEnv(baseURL)))
+}
+
+// `github` サブコマンドの引数からクライアントを生成（トークンは環境変数からのみ読む）
+func parseGitHubClient(args []string, w io.Writer) (githubClient, error) {
+	def := newGitHubClientFromEnv()
+	fs := flag.NewFlagSet("github", flag.ContinueOnError)
+	fs.SetOutput(w)
+	baseURL := fs.String("api-url", def.baseURL, "APIのベースURL（GitHub Enterprise の場合は https://HOST/api/v3）")
+	timeout := fs.Duration("timeout", constants.GitHubAPITimeout, "リクエストのタイムアウト")
+	if err := fs.Parse(args); err != nil {
+		return githubClient{}, err
+	}
+	if _, err := url.ParseRequestURI(*baseURL); err != nil {
+		return githubClient{}, fmt.Errorf("APIのベースURLが正しくありません: %w", err)
+	}
+	if *timeout <= 0 {
+		return githubClient{}, fmt.Errorf("タイムアウトは正の値で指定してください: %s", *timeout)
+	}
+	return newGitHubClient(*baseURL, *timeout, os.Getenv(githubTokenEnv(*baseURL))), nil
+}
+
+// 認証付きで呼び出すか
+func (c githubClient) authenticated() bool {
+	return c.token != ""
+}
+
+// ユーザー情報を取得
+func (c githubClient) User(username string) (*githubUser, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/users/"+url.PathEscape(username), nil)
+	if err != nil {
+		return nil, err
+	}
+	// User-Agentヘッダーを設定（GitHub API要件）
+	req.Header.Set("User-Agent", constants.AppName)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.authenticated() {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ネットワークエラー: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// ステータスコードのチェック
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("ユーザー '%s' が見つかりません", username)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, fmt.Errorf("認証エラー: %s を確認してください", c.tokenEnv)
+	case (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return nil, rateLimitError(resp.Header.Get("X-RateLimit-Reset"), c)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("APIエラー: ステータスコード %d", resp.StatusCode)
+	}
+
+	// JSONパース
+	var user githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("JSONパースエラー: %w", err)
+	}
+	return &user, nil
+}
+
+// レート制限のエラー（reset はリセットされるUNIX時刻）
+func rateLimitError(reset string, c githubClient) error {
+	msg := "APIのレート制限に達しました"
+	if sec, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		msg += fmt.Sprintf("（%s にリセット）", time.Unix(sec, 0).Local().Format("15:04"))
+	}
+	if !c.authenticated() {
+		msg += fmt.Sprintf("\n%s を設定すると上限が緩和されます", c.tokenEnv)
+	}
+	return errors.New(msg)
+}
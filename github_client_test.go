@@ -0,0 +1,171 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// /users/{name} に応答するテスト用のGitHub APIサーバー（https、リクエストを requests に記録する）
+func newGitHubStubServer(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		switch r.URL.Path {
+		case "/api/v3/users/octocat":
+			w.Write([]byte(`{"login": "octocat", "name": "The Octocat", "public_repos": 8}`))
+		case "/api/v3/users/private":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/api/v3/users/limited":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1790000000")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// テスト用サーバーの証明書を信頼するクライアント
+func newGitHubStubClient(server *httptest.Server, token string) githubClient {
+	client := newGitHubClient(server.URL+"/api/v3/", time.Second, token)
+	client.http.Transport = server.Client().Transport
+	return client
+}
+
+func TestGitHubClient(t *testing.T) {
+	var requests []*http.Request
+	server := newGitHubStubServer(t, &requests)
+
+	t.Run("ベースURLとトークンを使う", func(t *testing.T) {
+		requests = nil
+		client := newGitHubStubClient(server, "secret-token")
+		user, err := client.User("octocat")
+		if err != nil || user.Login != "octocat" || user.PublicRepos != 8 {
+			t.Fatalf("ユーザー情報を取得できるべき、実際: %+v (%v)", user, err)
+		}
+		r := requests[0]
+		if r.Header.Get("Authorization") != "Bearer secret-token" || r.Header.Get("User-Agent") == "" {
+			t.Errorf("認証ヘッダーを送るべき、実際: %v", r.Header)
+		}
+	})
+
+	t.Run("トークンがなければ認証しない", func(t *testing.T) {
+		requests = nil
+		client := newGitHubStubClient(server, "")
+		client.User("octocat")
+		if _, ok := requests[0].Header["Authorization"]; ok || client.authenticated() {
+			t.Error("認証ヘッダーを送らないべき")
+		}
+	})
+
+	t.Run("https 以外のURLにはトークンを送らない", func(t *testing.T) {
+		var plainRequests []*http.Request
+		plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plainRequests = append(plainRequests, r)
+		}))
+		defer plain.Close()
+		client := newGitHubClient(plain.URL, time.Second, "secret-token")
+		client.User("octocat")
+		if _, ok := plainRequests[0].Header["Authorization"]; ok || client.authenticated() {
+			t.Error("平文のURLでは認証ヘッダーを送らないべき")
+		}
+	})
+
+	t.Run("エラーの応答", func(t *testing.T) {
+		client := newGitHubStubClient(server, "")
+		tests := []struct {
+			username string
+			want     string
+		}{
+			{"nobody", "'nobody' が見つかりません"},
+			{"private", "認証エラー"},
+			{"limited", "レート制限に達しました"},
+		}
+		for _, tt := range tests {
+			if _, err := client.User(tt.username); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: エラーに %q を含むべき、実際: %v", tt.username, tt.want, err)
+			}
+		}
+		if _, err := client.User("limited"); !strings.Contains(err.Error(), "GITHUB_ENTERPRISE_TOKEN を設定") {
+			t.Errorf("未認証ならトークンの設定を案内するべき、実際: %v", err)
+		}
+	})
+
+	t.Run("タイムアウト", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer slow.Close()
+		if _, err := newGitHubClient(slow.URL, 50*time.Millisecond, "").User("octocat"); err == nil || !strings.Contains(err.Error(), "ネットワークエラー") {
+			t.Errorf("タイムアウトはネットワークエラーになるべき、実際: %v", err)
+		}
+	})
+}
+
+func TestParseGitHubClient(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_API_URL", "")
+
+	client, err := parseGitHubClient(nil, io.Discard)
+	if err != nil || client.baseURL != "https://api.github.com" || client.token != "env-token" || client.http.Timeout != 10*time.Second {
+		t.Errorf("既定値と GITHUB_TOKEN を使うべき、実際: %+v (%v)", client, err)
+	}
+
+	client, err = parseGitHubClient([]string{"--api-url", "https://ghe.example.com/api/v3/", "--timeout", "3s"}, io.Discard)
+	if err != nil || client.baseURL != "https://ghe.example.com/api/v3" || client.http.Timeout != 3*time.Second {
+		t.Errorf("指定したURLとタイムアウトを使うべき、実際: %+v (%v)", client, err)
+	}
+	if client.authenticated() {
+		t.Error("github.com 以外のホストには GITHUB_TOKEN を送らないべき")
+	}
+
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "ghe-token")
+	if client, _ := parseGitHubClient([]string{"--api-url", "https://ghe.example.com/api/v3"}, io.Discard); client.token != "ghe-token" {
+		t.Errorf("github.com 以外のホストには GITHUB_ENTERPRISE_TOKEN を使うべき、実際: %q", client.token)
+	}
+	if client, _ := parseGitHubClient(nil, io.Discard); client.token != "env-token" {
+		t.Errorf("api.github.com には GITHUB_TOKEN を使うべき、実際: %q", client.token)
+	}
+
+	t.Setenv("GITHUB_API_URL", "https://ghe.example.com/api/v3")
+	if client, _ := parseGitHubClient(nil, io.Discard); client.baseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("GITHUB_API_URL を使うべき、実際: %q", client.baseURL)
+	}
+
+	for _, args := range [][]string{{"--api-url", "ghe"}, {"--timeout", "0s"}} {
+		if _, err := parseGitHubClient(args, io.Discard); err == nil {
+			t.Errorf("%v はエラーになるべき", args)
+		}
+	}
+}
+
+func TestGitHubModelWithClient(t *testing.T) {
+	var requests []*http.Request
+	server := newGitHubStubServer(t, &requests)
+	m := NewGitHubModelWithClient(newGitHubStubClient(server, "token"))
+	if !strings.Contains(m.View(), "GITHUB_ENTERPRISE_TOKEN で認証") {
+		t.Errorf("認証の状態を表示するべき:\n%s", m.View())
+	}
+
+	m.input.SetValue("octocat")
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(githubModel)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(apiResponse); ok {
+			newModel, _ = m.Update(msg)
+			m = newModel.(githubModel)
+		}
+	}
+	if m.state != stateSuccess || m.user.Name != "The Octocat" {
+		t.Errorf("指定したクライアントでユーザー情報を取得するべき、実際: %d %q", m.state, m.errorMsg)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -42,36 +44,135 @@ func main() {
 	}
 
 	var initialModel tea.Model
+	var flusher formFlusher // 終了後に書き出す送信先
+	var opts []tea.ProgramOption
+	// 画面の出力先（タイマーのベルも同じ出力先に鳴らす）
+	output := os.Stdout
 	switch app {
 	case "timer":
-		initialModel = NewTimerModel()
+		// セッションは追記専用のログに記録し、`timer report` で集計する
+		log, err := defaultTimerLog()
+		if err != nil {
+			fmt.Printf("Error opening timer log: %v", err)
+			os.Exit(1)
+		}
+		if len(os.Args) > 2 && os.Args[2] == "report" {
+			if err := runTimerReport(os.Args[3:], os.Stdout, log, time.Now()); err != nil {
+				fmt.Printf("Error creating timer report: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+		store, err := defaultTimerStateStore()
+		if err != nil {
+			fmt.Printf("Error opening timer state: %v", err)
+			os.Exit(1)
+		}
+		initialModel = NewTimerListModelWithStore(output, log, store)
 	case "counter":
-		initialModel = NewCounterModel()
+		// --step / --min / --max / --wrap で増減量と範囲を指定する
+		cfg, flags, err := parseCounterConfig(os.Args[2:], os.Stderr)
+		if err != nil {
+			fmt.Printf("Error parsing counter options: %v\n", err)
+			os.Exit(1)
+		}
+		// 名前付きカウンターの一覧は変更のたびに保存する（指定した設定は保存済みのカウンターにも適用する）
+		store, err := defaultCounterStore()
+		if err != nil {
+			fmt.Printf("Error opening counter store: %v", err)
+			os.Exit(1)
+		}
+		initialModel = NewCounterListModelWithStore(cfg, flags, store)
 	case "todo":
-		initialModel = NewTodoModel()
+		// --file で todo.txt / Markdown / JSON のファイルを直接開く
+		fs := flag.NewFlagSet("todo", flag.ExitOnError)
+		file := fs.String("file", "", "TODOファイルのパス（.txt / .md / .json）")
+		fs.Parse(os.Args[2:])
+
+		var store todoStore
+		var err error
+		if *file != "" {
+			store, err = newTodoStoreForPath(*file)
+		} else {
+			store, err = defaultTodoStore()
+		}
+		if err != nil {
+			fmt.Printf("Error opening todo store: %v", err)
+			os.Exit(1)
+		}
+		initialModel = NewTodoModelWithStore(store)
 	case "form":
-		initialModel = NewFormModel()
+		// --spec でフォーム定義、--json / --csv / --post / --out / --stdout で送信先を指定する
+		formOpts, err := parseFormOptions(os.Args[2:], os.Stderr, os.Stdout)
+		if err != nil {
+			fmt.Printf("Error parsing form options: %v\n", err)
+			os.Exit(1)
+		}
+		schema := registrationFormSchema()
+		if formOpts.specPath != "" {
+			if schema, err = loadFormSchema(formOpts.specPath); err != nil {
+				fmt.Printf("Error loading form spec: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		m := NewFormModelWithSink(schema, formOpts.sink)
+		// フォーム定義を使う場合は対話的な入力として、送信したらそのまま終了する
+		m.quitOnSubmit = formOpts.specPath != ""
+		// 入力途中で終了した場合は下書きを保存し、次に開いた時に再開できるようにする
+		if store, err := defaultFormDraftStore(); err == nil {
+			m.drafts = store
+		}
+		initialModel = m
+		if f, ok := formOpts.sink.(formFlusher); ok {
+			// 標準出力は回答に使うので、画面は標準エラー出力に描画する
+			flusher = f
+			output = os.Stderr
+		}
 	case "github":
-		initialModel = NewGitHubModel()
+		// --api-url で GitHub Enterprise などに向け、GITHUB_TOKEN（github.com 以外は GITHUB_ENTERPRISE_TOKEN）があれば認証付きで呼び出す
+		client, err := parseGitHubClient(os.Args[2:], os.Stderr)
+		if err != nil {
+			fmt.Printf("Error parsing github options: %v\n", err)
+			os.Exit(1)
+		}
+		initialModel = NewGitHubModelWithClient(client)
 	case "dashboard":
-		initialModel = NewDashboardModel()
+		initialModel = NewDashboardModelWithBell(output)
 	default:
 		fmt.Println("使用方法:")
 		fmt.Println("  go run . counter    # カウンターアプリ")
+		fmt.Println("  go run . counter --step 5 --min 0 --max 100 [--wrap]  # 増減量と範囲を指定")
 		fmt.Println("  go run . timer      # タイマーアプリ")
+		fmt.Println("  go run . timer report --week [--csv]  # 計測時間の集計")
 		fmt.Println("  go run . todo       # TODOリストアプリ")
+		fmt.Println("  go run . todo --file tasks.md  # todo.txt / Markdownファイルを開く")
 		fmt.Println("  go run . form       # フォームアプリ")
+		fmt.Println("  go run . form --json answers.jsonl | --csv answers.csv | --post URL | --out answer.json | --stdout  # 回答の送信先")
+		fmt.Println("  go run . form --spec survey.yaml  # 定義ファイルのフォームに入力し、回答をJSONで標準出力に書き出す")
 		fmt.Println("  go run . github     # GitHub APIアプリ")
+		fmt.Println("  go run . github --api-url https://HOST/api/v3  # GitHub Enterprise（GITHUB_ENTERPRISE_TOKEN で認証）")
 		fmt.Println("  go run . dashboard  # 統合ダッシュボード")
 		os.Exit(0)
 	}
 
 	// Create a new program
-	p := tea.NewProgram(initialModel)
+	opts = append(opts, tea.WithOutput(output))
+	p := tea.NewProgram(initialModel, opts...)
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)
 	}
+	if flusher != nil {
+		if err := flusher.Flush(); err != nil {
+			fmt.Printf("Error writing form answers: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	// 送信せずに終了した場合はシェルスクリプトで判別できるように失敗を返す
+	if m, ok := finalModel.(formModel); ok && m.quitOnSubmit && !m.submitted {
+		os.Exit(1)
+	}
 }
\ No newline at end of file
@@ -3,6 +3,11 @@ package constants
 
 import "time"
 
+// Application identity
+const (
+	AppName = "bubbletea-learning"
+)
+
 // Application dimensions
 const (
 	DefaultWidth        = 50
@@ -16,12 +21,42 @@ const (
 // Timer constants
 const (
 	TickInterval = 100 * time.Millisecond
+
+	DefaultCountdown       = 5 * time.Minute
+	PomodoroWork           = 25 * time.Minute
+	PomodoroShortBreak     = 5 * time.Minute
+	PomodoroLongBreak      = 15 * time.Minute
+	PomodoroLongBreakEvery = 4 // 長い休憩までの作業回数
+	TimerMaxLapRows        = 5 // 表示するラップの最大行数
+	TimerNameMaxLength     = 30
+	TimerLogFileName       = "timer_log.jsonl" // 計測したセッションの追記専用ログ
+	TimerStateFileName     = "timer_state.json" // 終了時のタイマーの状態
+)
+
+// Counter constants
+const (
+	CounterHistoryMax      = 100 // 元に戻せる変更の最大数
+	CounterSparklineWidth  = 20  // スパークラインに表示する値の数
+	CounterPrefixMaxDigits = 6   // 数値プレフィックスの最大桁数
+	CounterNameMaxLength   = 30
+	CounterFileName        = "counters.json" // 名前付きカウンターの一覧
+)
+
+// TODO constants
+const (
+	TodoFileName       = "todo.json"
+	TodoTitleMaxLength = 100
+	TodoNoteMaxLength  = 1000
 )
 
 // Form constants
 const (
 	FormFieldMaxLength = 50
 	EmailMaxLength     = 100
+	FormTextareaHeight = 4                  // 複数行テキストの表示行数
+	FormCheckTimeout   = 5 * time.Second    // 非同期の検証の問い合わせのタイムアウト
+	FormSubmitTimeout  = 10 * time.Second   // HTTPの送信先へのPOSTのタイムアウト
+	FormDraftFileName  = "form_drafts.json" // 入力途中のフォームの下書き（フォームIDごと）
 )
 
 // GitHub API constants
@@ -0,0 +1,115 @@
+// Package storage provides helpers for persisting application data to local files.
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// DataDir returns the application data directory following the XDG Base Directory spec.
+// $XDG_DATA_HOME is used when set, otherwise ~/.local/share.
+func DataDir() (string, error) {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("ホームディレクトリの取得に失敗: %w", err)
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, constants.AppName), nil
+}
+
+// DataPath returns the path of the named file inside DataDir.
+func DataPath(name string) (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// WriteFileAtomic writes data to a temporary file in the same directory and renames it
+// over path, so a crash never leaves a partially written file behind.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("ディレクトリの作成に失敗: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("一時ファイルの作成に失敗: %w", err)
+	}
+	tmpName := tmp.Name()
+	// rename に成功した後は存在しないので Remove は失敗しても無視してよい
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("一時ファイルへの書き込みに失敗: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("一時ファイルの同期に失敗: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("一時ファイルのクローズに失敗: %w", err)
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return fmt.Errorf("パーミッションの設定に失敗: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("ファイルの置き換えに失敗: %w", err)
+	}
+	return nil
+}
+
+// SaveJSON encodes v as indented JSON and writes it to path atomically.
+func SaveJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("JSONエンコードに失敗: %w", err)
+	}
+	return WriteFileAtomic(path, append(data, '\n'), 0o644)
+}
+
+// LoadJSON reads the JSON file at path into v.
+// A missing file is reported as an error wrapping fs.ErrNotExist.
+func LoadJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("JSONパースエラー (%s): %w", path, err)
+	}
+	return nil
+}
+
+// AppendJSONLine encodes v as a single line of JSON and appends it to path,
+// creating the file (and its directory) if needed. Used for append-only logs.
+func AppendJSONLine(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("JSONエンコードに失敗: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("ディレクトリの作成に失敗: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("ファイルを開けません: %w", err)
+	}
+	// 1行を1回の Write で書き込み、途中まで書かれた行が残りにくいようにする
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("ファイルへの追記に失敗: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,81 @@
+package styles
+
+import "strings"
+
+// bigFont is a 5-row block font for digits and the separators used by
+// timers and counters. '#' marks a filled pixel.
+var bigFont = map[rune][5]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {"  #", "  #", "  #", "  #", "  #"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {" ", "#", " ", "#", " "},
+	'.': {" ", " ", " ", " ", "#"},
+	'-': {"   ", "   ", "###", "   ", "   "},
+	' ': {" ", " ", " ", " ", " "},
+}
+
+// bigScales are the pixel sizes (columns x rows) tried from largest to smallest.
+// Terminal cells are about twice as tall as they are wide, so pixels are wider than tall.
+var bigScales = [][2]int{{6, 3}, {4, 2}, {2, 1}, {1, 1}}
+
+// BigDigits renders s in a large block font, scaled to the largest size that
+// fits in width columns and height rows. A height of 0 means the height is
+// unknown and not limited. It returns s unchanged and false when s contains
+// characters the font does not have or the smallest size does not fit.
+func BigDigits(s string, width, height int) (string, bool) {
+	var glyphs [][5]string
+	for _, r := range s {
+		g, ok := bigFont[r]
+		if !ok {
+			return s, false
+		}
+		glyphs = append(glyphs, g)
+	}
+	if len(glyphs) == 0 {
+		return s, false
+	}
+
+	for _, scale := range bigScales {
+		px, py := scale[0], scale[1]
+		if bigWidth(glyphs, px) <= width && (height == 0 || 5*py <= height) {
+			return renderBig(glyphs, px, py), true
+		}
+	}
+	return s, false
+}
+
+// bigWidth returns the rendered width of glyphs with one pixel of spacing between them.
+func bigWidth(glyphs [][5]string, px int) int {
+	w := 0
+	for _, g := range glyphs {
+		w += len(g[0]) * px
+	}
+	return w + (len(glyphs)-1)*px
+}
+
+// renderBig draws glyphs with each pixel scaled to px columns and py rows.
+func renderBig(glyphs [][5]string, px, py int) string {
+	var lines []string
+	for row := 0; row < 5; row++ {
+		var b strings.Builder
+		for i, g := range glyphs {
+			if i > 0 {
+				b.WriteString(strings.Repeat(" ", px))
+			}
+			for _, c := range g[row] {
+				b.WriteString(strings.Repeat(string(c), px))
+			}
+		}
+		for j := 0; j < py; j++ {
+			lines = append(lines, b.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,41 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBigDigits(t *testing.T) {
+	t.Run("幅に合わせて拡大率を選ぶ", func(t *testing.T) {
+		large, ok := BigDigits("12", 100, 0)
+		if !ok {
+			t.Fatal("十分な幅があれば大きな数字になるべき")
+		}
+		small, ok := BigDigits("12", 7, 0)
+		if !ok {
+			t.Fatal("最小の大きさなら7桁に収まるべき")
+		}
+		if strings.Count(small, "\n") != 4 || strings.Count(large, "\n") <= 4 {
+			t.Errorf("幅が広いほど大きく描画されるべき:\n%s\n\n%s", small, large)
+		}
+	})
+
+	t.Run("高さの制限", func(t *testing.T) {
+		big, ok := BigDigits("12", 100, 5)
+		if !ok || strings.Count(big, "\n") != 4 {
+			t.Errorf("5行に収まる大きさで描画されるべき:\n%s", big)
+		}
+		if _, ok := BigDigits("12", 100, 4); ok {
+			t.Error("5行未満では描画できないべき")
+		}
+	})
+
+	t.Run("収まらない場合と未対応の文字", func(t *testing.T) {
+		if s, ok := BigDigits("00:00", 10, 0); ok || s != "00:00" {
+			t.Error("幅が足りなければ元の文字列を返すべき")
+		}
+		if _, ok := BigDigits("1a", 100, 0); ok {
+			t.Error("フォントにない文字は描画できないべき")
+		}
+	})
+}
@@ -86,6 +86,16 @@ var (
 	CounterZeroStyle = lipgloss.NewStyle().
 		Foreground(WarningColor).
 		Bold(true)
+
+	// CounterColors are the colors a named counter can be given instead of the sign-based styles
+	CounterColors = []lipgloss.Color{
+		PrimaryColor,
+		SecondaryColor,
+		lipgloss.Color("13"), // Magenta
+		WarningColor,
+		SuccessColor,
+		ErrorColor,
+	}
 )
 
 // Dashboard specific styles
@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/ktny/bubbletea-learning/pkg/constants"
 	"github.com/ktny/bubbletea-learning/pkg/styles"
 )
@@ -23,15 +26,40 @@ const (
 
 // タイマーモデル
 type timerModel struct {
+	name       string        // タイマー名（複数のタイマーを区別する）
 	duration   time.Duration // 経過時間
 	state      timerState    // タイマーの状態
 	startTime  time.Time     // 開始時刻
 	pausedTime time.Duration // 一時停止時の累積時間
+
+	mode          timerMode       // ストップウォッチ・カウントダウン・ポモドーロ
+	target        time.Duration   // カウントダウンの目標時間
+	pomodoro      pomodoroConfig  // ポモドーロの各区間の長さ
+	phase         pomodoroPhase   // ポモドーロの現在の区間
+	completedWork int             // 終えた作業区間の数
+	laps          []time.Duration // ラップを記録した時点の経過時間（スプリット）
+	editing       bool            // 時間の入力中か
+	input         textinput.Model // 目標時間・区間の長さの入力欄
+	err           error           // 入力エラー
+	bell          io.Writer       // 終了時のベルの出力先（プログラムの出力先を渡す、nilの場合は鳴らさない）
+	clock         timerClock      // 時計（テストで差し替え可能）
+
+	sessionStart time.Time    // 計測中のセッションの開始時刻（リセット・終了でログに記録）
+	pauses       []timerPause // セッション中の一時停止
+	log          timerLog     // セッションの記録先（nilの場合は記録しない）
+
+	width  int // 端末の幅（大きな数字の表示に使う、0の場合は通常の表示）
+	height int // 端末の高さ（0の場合は高さを制限しない）
 }
 
 // タイマーモデルのコンストラクタ
 func NewTimerModel() timerModel {
-	return timerModel{}.handleReset()
+	return timerModel{
+		target:   constants.DefaultCountdown,
+		pomodoro: defaultPomodoroConfig(),
+		input:    newTimerInput(),
+		clock:    realClock{},
+	}.handleReset()
 }
 
 // Init - 初期化時のコマンド
@@ -39,54 +67,93 @@ func (m timerModel) Init() tea.Cmd {
 	return nil
 }
 
-// tick コマンドを生成する関数
-func tickCmd() tea.Cmd {
-	return tea.Tick(constants.TickInterval, func(t time.Time) tea.Msg {
+// tick コマンドを生成する関数（次の TickInterval の区切りに合わせる）
+func tickCmd(c timerClock) tea.Cmd {
+	return c.Tick(nextTickDelay(c.Now(), constants.TickInterval), func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
 // handleStartStop - Start/Stop トグル処理
 func (m timerModel) handleStartStop() (timerModel, tea.Cmd) {
+	m = m.toggle()
+	if m.state == running {
+		return m, tickCmd(m.clock)
+	}
+	return m, nil
+}
+
+// 状態のみを切り替える（tickの予約は呼び出し側で行う）
+func (m timerModel) toggle() timerModel {
 	switch m.state {
 	case stopped:
 		m.state = running
-		m.startTime = time.Now()
+		m.startTime = m.clock.Now()
 		m.pausedTime = 0
-		return m, tickCmd()
+		if m.sessionStart.IsZero() {
+			m.sessionStart = m.startTime
+		}
 	case running:
 		m.state = paused
+		// 直前のtickではなく停止した時刻までの経過時間を残す
+		m.duration = m.clock.Now().Sub(m.startTime) + m.pausedTime
 		m.pausedTime = m.duration
-		return m, nil
+		m.pauses = append(m.pauses, timerPause{start: m.clock.Now()})
 	case paused:
 		m.state = running
-		m.startTime = time.Now()
-		return m, tickCmd()
+		m.startTime = m.clock.Now()
+		if n := len(m.pauses); n > 0 {
+			m.pauses[n-1].end = m.startTime
+		}
 	}
-	return m, nil
+	return m
 }
 
-// handleReset - リセット処理
+// handleReset - リセット処理（計測中のセッションはログに記録）
 func (m timerModel) handleReset() timerModel {
+	m = m.recordSession()
 	m.state = stopped
 	m.duration = 0
 	m.pausedTime = 0
 	m.startTime = time.Time{}
+	m.laps = nil
 	return m
 }
 
 // handleTick - tick メッセージ処理
 func (m timerModel) handleTick() (tea.Model, tea.Cmd) {
+	if m.state != running {
+		return m, nil
+	}
+	m, cmd := m.advance()
 	if m.state == running {
-		// 現在時刻から開始時刻を引いて、一時停止時の累積時間を加算
-		m.duration = time.Since(m.startTime) + m.pausedTime
-		return m, tickCmd() // 次のtickを予約
+		return m, tickCmd(m.clock) // 次のtickを予約
+	}
+	return m, cmd
+}
+
+// 経過時間を現在時刻に合わせる（区間が終わった場合は終了処理のコマンドを返す）
+func (m timerModel) advance() (timerModel, tea.Cmd) {
+	if m.state != running {
+		return m, nil
+	}
+	// 現在時刻から開始時刻を引いて、一時停止時の累積時間を加算
+	m.duration = m.clock.Now().Sub(m.startTime) + m.pausedTime
+	if limit := m.limit(); limit > 0 && m.duration >= limit {
+		return m.finish()
 	}
 	return m, nil
 }
 
 // Update - メッセージ処理
 func (m timerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// 時間の入力中はtextinputに委譲
+	if m.editing {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m.updateInput(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// Start/Stop処理の判定
@@ -103,15 +170,35 @@ func (m timerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch string(msg.Runes) {
 			case "r":
 				return m.handleReset(), nil
+			case "m":
+				return m.cycleMode(), nil
+			case "d":
+				return m.startEditing()
+			case "l":
+				return m.recordLap()
+			case "n":
+				if m.mode == modePomodoro {
+					return m.nextPhase(), nil
+				}
 			case "q":
-				return m, tea.Quit
+				return m.recordSession(), tea.Quit
 			}
 		case tea.KeyCtrlC:
-			return m, tea.Quit
+			return m.recordSession(), tea.Quit
 		}
 
 	case tickMsg:
 		return m.handleTick()
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	default:
+		// カーソル点滅などtextinput向けのメッセージ
+		if m.editing {
+			return m.updateInput(msg)
+		}
 	}
 
 	return m, nil
@@ -125,32 +212,102 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d.%d", minutes, seconds, milliseconds)
 }
 
-// View - UIの描画
-func (m timerModel) View() string {
-	timeStyle := styles.SuccessStyle.Copy().
-		Width(12)
-
-	// 状態テキスト
-	var stateText string
+// 状態テキスト
+func (m timerModel) stateText() string {
 	switch m.state {
-	case stopped:
-		stateText = "停止中"
 	case running:
-		stateText = "実行中"
+		return "実行中"
 	case paused:
-		stateText = "一時停止中"
+		return "一時停止中"
+	}
+	return "停止中"
+}
+
+// 表示する時間（カウントダウン・ポモドーロは残り時間）
+func (m timerModel) shown() time.Duration {
+	if m.mode == modeStopwatch {
+		return m.duration
+	}
+	return m.remaining()
+}
+
+// モードの表示
+func (m timerModel) modeText() string {
+	modeText := m.mode.String()
+	switch m.mode {
+	case modeCountdown:
+		modeText += fmt.Sprintf("（%s）", formatDuration(m.target))
+	case modePomodoro:
+		modeText += fmt.Sprintf(" 🍅 %s  完了: %d回", m.phase, m.completedWork)
+	}
+	return modeText
+}
+
+// 入力欄・エラー・ラップの表示
+func (m timerModel) extraView() string {
+	var extra string
+	if m.editing {
+		extra += "\n\n" + m.input.View()
 	}
+	if m.err != nil {
+		extra += "\n" + styles.ErrorStyle.Render("❌ "+m.err.Error())
+	}
+	if table := m.lapTable(); table != "" {
+		extra += "\n\n" + styles.ValueStyle.Render(table)
+	}
+	return extra
+}
+
+// 操作のヘルプ
+func (m timerModel) helpText() string {
+	if m.editing {
+		return "Enter: 確定  Esc: キャンセル\n"
+	}
+	help := "s/スペース: スタート/ストップ\n" +
+		"r: リセット  l: ラップ  m: モード切り替え\n"
+	switch m.mode {
+	case modeCountdown:
+		help += "d: 時間を設定\n"
+	case modePomodoro:
+		help += "d: 区間の長さを設定  n: 次の区間へ\n"
+	}
+	return help
+}
+
+// 時間の表示（端末に収まれば大きな数字、収まらなければ通常の表示）
+func (m timerModel) timeView() string {
+	text := formatDuration(m.shown())
+	plain := styles.SuccessStyle.Copy().
+		Width(12).
+		Render(text)
+
+	// 大きな数字に使える行数は、時間以外の表示を除いた残り
+	rows := 0
+	if m.height > 0 {
+		rows = max(m.height-lipgloss.Height(m.render(plain))+1, 1)
+	}
+	available := m.width - styles.BorderStyle.GetHorizontalFrameSize()
+	if big, ok := styles.BigDigits(text, available, rows); ok {
+		return styles.SuccessStyle.Render(big)
+	}
+	return plain
+}
+
+// View - UIの描画
+func (m timerModel) View() string {
+	return m.render(m.timeView())
+}
 
+// 時間の表示を指定して描画
+func (m timerModel) render(timeView string) string {
 	content := fmt.Sprintf(
-		"%s\n\n%s\n\n%s\n\n%s",
+		"%s\n\n%s\n\n%s\n%s%s\n\n%s",
 		styles.TitleStyle.Render(constants.TimerTitle),
-		timeStyle.Render(formatDuration(m.duration)),
-		styles.DimmedStyle.Render(fmt.Sprintf("状態: %s", stateText)),
-		styles.HelpStyle.Render(
-			"s/スペース: スタート/ストップ\n"+
-				"r: リセット\n"+
-				constants.QuitHelp,
-		),
+		timeView,
+		styles.DimmedStyle.Render(fmt.Sprintf("状態: %s", m.stateText())),
+		styles.DimmedStyle.Render("モード: "+m.modeText()),
+		m.extraView(),
+		styles.HelpStyle.Render(m.helpText()+constants.QuitHelp),
 	)
 
 	return styles.BorderStyle.Render(content)
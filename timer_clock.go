@@ -0,0 +1,34 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timerClock - タイマーが使う時計（テストで差し替え可能）
+type timerClock interface {
+	// Now - 現在時刻
+	Now() time.Time
+	// Tick - d 後に fn の結果を送るコマンド
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+}
+
+// 実際の時刻を使う時計
+type realClock struct{}
+
+// Now - 現在時刻
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Tick - tea.Tick で d 後にメッセージを送る
+func (realClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
+// 次の区切り（interval の倍数の時刻）までの待ち時間
+// 前回のtickからの遅れを次の待ち時間で吸収するので、ずれが積み重ならない
+func nextTickDelay(now time.Time, interval time.Duration) time.Duration {
+	return now.Truncate(interval).Add(interval).Sub(now)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用の時計（Advance で時刻を進める）
+// モデルは値でコピーされるので、ポインタで共有して全てのコピーから同じ時刻を見る
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration // Tick で予約された待ち時間
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+// Now - 現在時刻
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// Advance - 時刻を d だけ進める
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// Tick - コマンドの実行時に d だけ時刻を進めてメッセージを返す
+func (c *fakeClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	c.delays = append(c.delays, d)
+	return func() tea.Msg {
+		c.Advance(d)
+		return fn(c.now)
+	}
+}
+
+// テスト用の時計を使うタイマー
+func newFakeClockTimer() (timerModel, *fakeClock) {
+	clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+	m := NewTimerModel()
+	m.clock = clock
+	return m, clock
+}
+
+// tickコマンドを実行して結果のメッセージでモデルを更新
+func runTimerTick(t *testing.T, m timerModel, cmd tea.Cmd) (timerModel, tea.Cmd) {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("tickコマンドが予約されているべき")
+	}
+	newModel, next := m.Update(cmd())
+	return newModel.(timerModel), next
+}
+
+func TestNextTickDelay(t *testing.T) {
+	base := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		{"区切りちょうど", base, 100 * time.Millisecond},
+		{"区切りの直後", base.Add(130 * time.Millisecond), 70 * time.Millisecond},
+		{"区切りの直前", base.Add(199 * time.Millisecond), time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := nextTickDelay(tt.now, 100*time.Millisecond); d != tt.expected {
+				t.Errorf("nextTickDelay = %v, 期待値: %v", d, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimerClock(t *testing.T) {
+	t.Run("tickは時刻の区切りに揃う", func(t *testing.T) {
+		m, clock := newFakeClockTimer()
+		clock.Advance(30 * time.Millisecond)
+
+		m, cmd := m.handleStartStop()
+		for i := 0; i < 3; i++ {
+			m, cmd = runTimerTick(t, m, cmd)
+		}
+
+		expected := []time.Duration{70 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond}
+		for i, d := range clock.delays {
+			if d != expected[i] {
+				t.Errorf("%d回目の待ち時間は%vであるべき、実際: %v", i+1, expected[i], d)
+			}
+		}
+		if m.duration != 270*time.Millisecond {
+			t.Errorf("経過時間は270ミリ秒であるべき、実際: %v", m.duration)
+		}
+	})
+
+	t.Run("tickが遅れても次の区切りで取り戻す", func(t *testing.T) {
+		m, clock := newFakeClockTimer()
+		m, _ = m.handleStartStop()
+
+		// 処理が詰まって40ミリ秒遅れたtick
+		clock.Advance(140 * time.Millisecond)
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerModel)
+
+		if m.duration != 140*time.Millisecond {
+			t.Errorf("経過時間は時計から求めるべき、実際: %v", m.duration)
+		}
+		if last := clock.delays[len(clock.delays)-1]; last != 60*time.Millisecond {
+			t.Errorf("次の待ち時間は60ミリ秒に縮むべき、実際: %v", last)
+		}
+	})
+
+	t.Run("一時停止した時刻までの時間を残す", func(t *testing.T) {
+		m, clock := newFakeClockTimer()
+		m, _ = m.handleStartStop()
+		clock.Advance(2*time.Second + 50*time.Millisecond)
+
+		m, _ = m.handleStartStop() // 一時停止
+		clock.Advance(time.Minute)
+		m, _ = m.handleStartStop() // 再開
+		clock.Advance(time.Second)
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerModel)
+
+		if m.duration != 3*time.Second+50*time.Millisecond {
+			t.Errorf("一時停止中の時間は含まれないべき、実際: %v", m.duration)
+		}
+	})
+}
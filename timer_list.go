@@ -0,0 +1,447 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/styles"
+)
+
+// 複数のタイマーは1つのtickMsgの流れを共有する。
+// 各タイマーは tickCmd を返さず（toggle / advance を使う）、
+// 一覧が実行中のタイマーがある間だけtickを予約する。
+
+// 最後のタイマーは削除できない
+var errRemoveLastTimer = errors.New("最後のタイマーは削除できません")
+
+// セッションの記録先がない（レポートを表示できない）
+var errNoTimerLog = errors.New("セッションの記録先が設定されていません")
+
+// タイマー名の入力の種類
+type timerNaming int
+
+const (
+	namingNone   timerNaming = iota
+	namingAdd                // 新しいタイマーの名前
+	namingRename             // 選択中のタイマーの名前変更
+)
+
+// 一覧の名前の列幅
+const timerNameColumnWidth = 12
+
+// 選択中のタイマー行のスタイル
+var timerSelectedStyle = lipgloss.NewStyle().
+	Foreground(styles.PrimaryColor).
+	Bold(true)
+
+// 複数タイマーの一覧モデル
+type timerListModel struct {
+	timers     []timerModel
+	cursor     int             // 選択中のタイマー
+	ticking    bool            // tickを予約済みか（二重に予約しないため）
+	naming     timerNaming     // 名前の入力中か
+	input      textinput.Model // 名前の入力欄
+	added      int             // これまでに追加した数（デフォルト名の番号）
+	err        error
+	clock      timerClock      // 全タイマーで共有する時計
+	bell       io.Writer       // 全タイマーで共有するベルの出力先
+	log        timerLog        // 全タイマーで共有するセッションの記録先
+	store      timerStateStore // 終了時の状態の保存先（nilの場合は保存しない）
+	saveFailed bool            // 終了時の保存に失敗したか（次の終了では保存できなくても終了する）
+	report     string          // 表示中の今週のレポート（空の場合は一覧を表示）
+	width      int             // 端末の幅（選択中のタイマーを大きな数字で表示する）
+	height     int             // 端末の高さ（0の場合は高さを制限しない）
+}
+
+// NewTimerListModel - タイマー1つで始まる一覧を生成（bell はプログラムの出力先、nilの場合はベルを鳴らさない）
+func NewTimerListModel(bell io.Writer) timerListModel {
+	return newTimerList(realClock{}, bell, nil)
+}
+
+// NewTimerListModelWithStore - セッションをログに記録し、終了時の状態を保存する一覧を生成
+func NewTimerListModelWithStore(bell io.Writer, log timerLog, store timerStateStore) timerListModel {
+	m := newTimerList(realClock{}, bell, log)
+	m.store = store
+	return m
+}
+
+// 時計・ベルの出力先・セッションの記録先を指定して一覧を生成
+func newTimerList(clock timerClock, bell io.Writer, log timerLog) timerListModel {
+	input := textinput.New()
+	input.Prompt = "名前: "
+	input.CharLimit = constants.TimerNameMaxLength
+	input.Width = constants.TimerNameMaxLength
+
+	m := timerListModel{input: input, clock: clock, bell: bell, log: log}
+	return m.addTimer("")
+}
+
+// Init - 初期化時のコマンド（前回終了時の状態を読み込む）
+func (m timerListModel) Init() tea.Cmd {
+	if m.store != nil {
+		return loadTimerStateCmd(m.store)
+	}
+	return nil
+}
+
+// 前回終了時の状態を復元（実行中のタイマーがあればtickを予約）
+func (m timerListModel) handleLoaded(msg timerStateLoadedMsg) (timerListModel, tea.Cmd) {
+	if msg.err != nil {
+		// 未保存の場合は初期状態のまま始める
+		if !errors.Is(msg.err, fs.ErrNotExist) {
+			m.err = msg.err
+		}
+		return m, nil
+	}
+	if len(msg.snapshot.timers) == 0 {
+		return m, nil
+	}
+	m.timers = msg.snapshot.timers
+	var cmds []tea.Cmd
+	for i := range m.timers {
+		m.timers[i].clock = m.clock
+		m.timers[i].bell = m.bell
+		m.timers[i].log = m.log
+		var cmd tea.Cmd
+		m.timers[i], cmd = m.timers[i].finishOverdue()
+		cmds = append(cmds, cmd)
+	}
+	m.cursor = min(max(msg.snapshot.cursor, 0), len(m.timers)-1)
+	m.added = max(msg.snapshot.added, len(m.timers))
+	m, cmd := m.scheduleTick()
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+// 選択中のタイマー
+func (m timerListModel) current() timerModel {
+	return m.timers[m.cursor]
+}
+
+// タイマーを末尾に追加して選択（名前が空の場合は「タイマーN」）
+func (m timerListModel) addTimer(name string) timerListModel {
+	m.added++
+	if name == "" {
+		name = fmt.Sprintf("タイマー%d", m.added)
+	}
+	t := NewTimerModel()
+	t.name = name
+	t.clock = m.clock
+	t.bell = m.bell
+	t.log = m.log
+	m.timers = append(m.timers, t)
+	m.cursor = len(m.timers) - 1
+	return m
+}
+
+// 選択中のタイマーを削除
+func (m timerListModel) removeTimer() timerListModel {
+	if len(m.timers) <= 1 {
+		m.err = errRemoveLastTimer
+		return m
+	}
+	// 計測中のセッションは記録してから削除する
+	if err := m.current().recordSession().err; err != nil {
+		m.err = err
+		return m
+	}
+	m.timers = append(m.timers[:m.cursor:m.cursor], m.timers[m.cursor+1:]...)
+	m.cursor = min(m.cursor, len(m.timers)-1)
+	m.err = nil
+	return m
+}
+
+// 実行中のタイマーがあるか
+func (m timerListModel) anyRunning() bool {
+	for _, t := range m.timers {
+		if t.state == running {
+			return true
+		}
+	}
+	return false
+}
+
+// 実行中のタイマーがあり、まだ予約していなければtickを予約する
+func (m timerListModel) scheduleTick() (timerListModel, tea.Cmd) {
+	if m.ticking || !m.anyRunning() {
+		return m, nil
+	}
+	m.ticking = true
+	return m, tickCmd(m.clock)
+}
+
+// 選択中のタイマーのスタート/ストップ
+func (m timerListModel) toggleCurrent() (timerListModel, tea.Cmd) {
+	m.timers[m.cursor] = m.current().toggle()
+	return m.scheduleTick()
+}
+
+// tickで全ての実行中のタイマーを進める
+func (m timerListModel) handleTick() (timerListModel, tea.Cmd) {
+	m.ticking = false
+	var cmds []tea.Cmd
+	for i, t := range m.timers {
+		var cmd tea.Cmd
+		m.timers[i], cmd = t.advance()
+		cmds = append(cmds, cmd)
+	}
+	m, cmd := m.scheduleTick()
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+// 終了（状態を保存し、実行中のタイマーは閉じている間も進み続ける）
+// 保存先がないか保存に失敗した場合は、計測中のセッションをログに記録する
+// 保存に失敗した場合は終了せずにエラーを表示し、もう一度終了すると保存できないまま終了する
+func (m timerListModel) quit() (timerListModel, tea.Cmd) {
+	if m.store != nil {
+		snapshot := timerListSnapshot{timers: m.timers, cursor: m.cursor, added: m.added}
+		err := m.store.Save(snapshot)
+		if err == nil || m.saveFailed {
+			return m, tea.Quit
+		}
+		m.saveFailed = true
+		m.err = fmt.Errorf("タイマーの状態を保存できません（計測中のセッションはログに記録しました。もう一度終了すると保存せずに終了します）: %w", err)
+		return m.recordSessions(), nil
+	}
+	return m.recordSessions(), tea.Quit
+}
+
+// 全てのタイマーの計測中のセッションをログに記録
+func (m timerListModel) recordSessions() timerListModel {
+	for i, t := range m.timers {
+		m.timers[i] = t.recordSession()
+	}
+	return m
+}
+
+// 今週のレポートを表示
+func (m timerListModel) showReport() timerListModel {
+	if m.log == nil {
+		m.err = errNoTimerLog
+		return m
+	}
+	sessions, err := m.log.Load()
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.report = buildTimerReport(sessions, timerReportDays(m.clock.Now(), true)).table()
+	return m
+}
+
+// 名前の入力を開始
+func (m timerListModel) startNaming(naming timerNaming) (timerListModel, tea.Cmd) {
+	m.naming = naming
+	m.input.SetValue("")
+	m.input.Placeholder = fmt.Sprintf("タイマー%d", m.added+1)
+	if naming == namingRename {
+		m.input.SetValue(m.current().name)
+		m.input.Placeholder = ""
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+// 名前入力中のメッセージ処理
+func (m timerListModel) updateNaming(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			name := strings.TrimSpace(m.input.Value())
+			switch m.naming {
+			case namingAdd:
+				m = m.addTimer(name)
+			case namingRename:
+				if name != "" {
+					m.timers[m.cursor].name = name
+				}
+			}
+			m.naming = namingNone
+			m.input.Blur()
+			return m, nil
+		case tea.KeyEsc:
+			m.naming = namingNone
+			m.input.Blur()
+			return m, nil
+		case tea.KeyCtrlC:
+			return m.quit()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Update - メッセージ処理
+func (m timerListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.naming != namingNone {
+		switch msg.(type) {
+		case tickMsg, timerStateLoadedMsg:
+		default:
+			return m.updateNaming(msg)
+		}
+	}
+
+	switch msg := msg.(type) {
+	case tickMsg:
+		return m.handleTick()
+
+	case timerStateLoadedMsg:
+		return m.handleLoaded(msg)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		// 時間の入力中は選択中のタイマーに全て渡す
+		if m.current().editing {
+			if msg.Type == tea.KeyCtrlC {
+				return m.quit()
+			}
+			return m.updateCurrent(msg)
+		}
+		m.err = nil
+
+		// レポートの表示中は閉じる・終了のみ
+		if m.report != "" {
+			switch {
+			case msg.Type == tea.KeyCtrlC || msg.String() == "q":
+				return m.quit()
+			case msg.Type == tea.KeyEsc || msg.String() == "R":
+				m.report = ""
+			}
+			return m, nil
+		}
+
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m.quit()
+		case tea.KeyUp:
+			m.cursor = max(m.cursor-1, 0)
+			return m, nil
+		case tea.KeyDown:
+			m.cursor = min(m.cursor+1, len(m.timers)-1)
+			return m, nil
+		case tea.KeySpace:
+			return m.toggleCurrent()
+		case tea.KeyRunes:
+			switch string(msg.Runes) {
+			case "k":
+				m.cursor = max(m.cursor-1, 0)
+				return m, nil
+			case "j":
+				m.cursor = min(m.cursor+1, len(m.timers)-1)
+				return m, nil
+			case "s":
+				return m.toggleCurrent()
+			case "a":
+				return m.startNaming(namingAdd)
+			case "e":
+				return m.startNaming(namingRename)
+			case "x":
+				return m.removeTimer(), nil
+			case "R":
+				return m.showReport(), nil
+			case "q":
+				return m.quit()
+			}
+		}
+		return m.updateCurrent(msg)
+
+	default:
+		// カーソル点滅などは入力中のタイマーへ
+		if m.current().editing {
+			return m.updateCurrent(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// 選択中のタイマーにメッセージを渡す
+func (m timerListModel) updateCurrent(msg tea.Msg) (tea.Model, tea.Cmd) {
+	newModel, cmd := m.current().Update(msg)
+	m.timers[m.cursor] = newModel.(timerModel)
+	return m, cmd
+}
+
+// View - UIの描画
+func (m timerListModel) View() string {
+	var rows []string
+	for i, t := range m.timers {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		// 全角の名前でも時間の列が揃うように表示幅で埋める
+		name := t.name + strings.Repeat(" ", max(timerNameColumnWidth-lipgloss.Width(t.name), 0))
+		row := fmt.Sprintf("%s%s %s  %s",
+			cursor, name, formatDuration(t.shown()), t.stateText())
+		if i == m.cursor {
+			row = timerSelectedStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	if m.report != "" {
+		return styles.BorderStyle.Render(fmt.Sprintf(
+			"%s\n\n%s\n\n%s",
+			styles.TitleStyle.Render(constants.TimerTitle+" - 今週のレポート"),
+			styles.ValueStyle.Render(m.report),
+			styles.HelpStyle.Render("R/Esc: 一覧に戻る\n"+constants.QuitHelp),
+		))
+	}
+
+	current := m.current()
+	extra := current.extraView()
+	if m.naming != namingNone {
+		extra += "\n\n" + m.input.View()
+	}
+	if m.err != nil {
+		extra += "\n" + styles.ErrorStyle.Render("❌ "+m.err.Error())
+	}
+
+	help := current.helpText()
+	if m.naming != namingNone {
+		help = "Enter: 確定  Esc: キャンセル\n"
+	} else if !current.editing {
+		help = "j/k: 選択  a: 追加  e: 名前変更  x: 削除  R: レポート\n" + help
+	}
+
+	render := func(list string) string {
+		return styles.BorderStyle.Render(fmt.Sprintf(
+			"%s\n\n%s\n\n%s%s\n\n%s",
+			styles.TitleStyle.Render(constants.TimerTitle),
+			list,
+			styles.DimmedStyle.Render("モード: "+current.modeText()),
+			extra,
+			styles.HelpStyle.Render(help+constants.QuitHelp),
+		))
+	}
+
+	// 選択中のタイマーは端末に収まれば大きな数字でも表示する
+	list := strings.Join(rows, "\n")
+	plain := render(list)
+	bigRows := 0
+	if m.height > 0 {
+		// 一覧の上に空行を挟んで追加するので、その分も除いた残り
+		bigRows = m.height - lipgloss.Height(plain) - 1
+		if bigRows < 1 {
+			return plain
+		}
+	}
+	available := m.width - styles.BorderStyle.GetHorizontalFrameSize()
+	if big, ok := styles.BigDigits(formatDuration(current.shown()), available, bigRows); ok {
+		return render(styles.SuccessStyle.Render(big) + "\n\n" + list)
+	}
+	return plain
+}
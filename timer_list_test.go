@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用の時計を使うタイマー一覧
+func newTimerListTestModel() (timerListModel, *fakeClock) {
+	clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+	return newTimerList(clock, nil, nil), clock
+}
+
+func sendTimerListKey(m timerListModel, key tea.KeyMsg) (timerListModel, tea.Cmd) {
+	newModel, cmd := m.Update(key)
+	return newModel.(timerListModel), cmd
+}
+
+// 名前を入力してタイマーを追加
+func addNamedTimer(m timerListModel, name string) timerListModel {
+	m, _ = sendTimerListKey(m, runeKey("a"))
+	m.input.SetValue(name)
+	m, _ = sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	return m
+}
+
+func timerNames(m timerListModel) []string {
+	var names []string
+	for _, t := range m.timers {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+func TestTimerList(t *testing.T) {
+	t.Run("初期状態はタイマー1つ", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+
+		if len(m.timers) != 1 || m.timers[0].name != "タイマー1" {
+			t.Errorf("デフォルト名のタイマーが1つあるべき、実際: %v", timerNames(m))
+		}
+	})
+
+	t.Run("aキーで名前を付けて追加", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+
+		m = addNamedTimer(m, "読書")
+		m = addNamedTimer(m, "")
+
+		if names := strings.Join(timerNames(m), ","); names != "タイマー1,読書,タイマー3" {
+			t.Errorf("タイマーが追加されるべき、実際: %s", names)
+		}
+		if m.cursor != 2 {
+			t.Errorf("追加したタイマーが選択されるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("Escで追加を取り消す", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+
+		m, _ = sendTimerListKey(m, runeKey("a"))
+		m, _ = sendTimerListKey(m, runeKey("q"))
+		m, _ = sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+		if len(m.timers) != 1 || m.naming != namingNone {
+			t.Error("タイマーは追加されないべき")
+		}
+	})
+
+	t.Run("eキーで名前を変更", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+
+		m, _ = sendTimerListKey(m, runeKey("e"))
+		if m.input.Value() != "タイマー1" {
+			t.Errorf("現在の名前が入力されているべき、実際: %q", m.input.Value())
+		}
+		m.input.SetValue("会議")
+		m, _ = sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m.timers[0].name != "会議" {
+			t.Errorf("名前が変更されるべき、実際: %s", m.timers[0].name)
+		}
+	})
+
+	t.Run("xキーで削除、最後の1つは削除できない", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+		m.cursor = 0
+
+		m, _ = sendTimerListKey(m, runeKey("x"))
+		if names := strings.Join(timerNames(m), ","); names != "読書" {
+			t.Errorf("選択中のタイマーが削除されるべき、実際: %s", names)
+		}
+
+		m, _ = sendTimerListKey(m, runeKey("x"))
+		if len(m.timers) != 1 || m.err != errRemoveLastTimer {
+			t.Error("最後のタイマーは削除できないべき")
+		}
+	})
+
+	t.Run("j/kキーで選択", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+
+		m, _ = sendTimerListKey(m, runeKey("k"))
+		m, _ = sendTimerListKey(m, runeKey("k"))
+		if m.cursor != 0 {
+			t.Errorf("先頭で止まるべき、実際: %d", m.cursor)
+		}
+		m, _ = sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		if m.cursor != 1 {
+			t.Errorf("次のタイマーを選択するべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("選択中のタイマーへのキー操作", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+
+		m, _ = sendTimerListKey(m, runeKey("m"))
+
+		if m.timers[1].mode != modeCountdown || m.timers[0].mode != modeStopwatch {
+			t.Error("選択中のタイマーのみモードが変わるべき")
+		}
+	})
+
+	t.Run("ベルは指定した出力先に鳴らす", func(t *testing.T) {
+		var bell bytes.Buffer
+		m := NewTimerListModel(&bell)
+		m = addNamedTimer(m, "読書")
+
+		for _, timer := range m.timers {
+			bellCmd(timer.bell)()
+		}
+		if bell.String() != "\a\a" {
+			t.Errorf("全てのタイマーのベルが出力先に書き込まれるべき、実際: %q", bell.String())
+		}
+		if NewTimerModel().bell != nil {
+			t.Error("出力先を指定しなければベルを鳴らさないべき")
+		}
+	})
+}
+
+func TestTimerListTick(t *testing.T) {
+	t.Run("複数のタイマーが1つのtickを共有する", func(t *testing.T) {
+		m, clock := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+
+		m, first := sendTimerListKey(m, runeKey("s"))
+		m.cursor = 0
+		m, second := sendTimerListKey(m, tea.KeyMsg{Type: tea.KeySpace})
+
+		if first == nil || second != nil {
+			t.Fatal("tickは1つだけ予約されるべき")
+		}
+
+		clock.Advance(time.Second)
+		newModel, cmd := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+
+		if m.timers[0].duration != time.Second || m.timers[1].duration != time.Second {
+			t.Errorf("全ての実行中のタイマーが進むべき: %v, %v", m.timers[0].duration, m.timers[1].duration)
+		}
+		if cmd == nil {
+			t.Error("次のtickが予約されるべき")
+		}
+	})
+
+	t.Run("停止中のタイマーは進まない", func(t *testing.T) {
+		m, clock := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+		m, _ = sendTimerListKey(m, runeKey("s"))
+
+		clock.Advance(2 * time.Second)
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+
+		if m.timers[0].duration != 0 || m.timers[1].duration != 2*time.Second {
+			t.Errorf("実行中のタイマーのみ進むべき: %v, %v", m.timers[0].duration, m.timers[1].duration)
+		}
+	})
+
+	t.Run("全て止まるとtickの予約をやめる", func(t *testing.T) {
+		m, clock := newTimerListTestModel()
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		m, _ = sendTimerListKey(m, runeKey("s"))
+
+		clock.Advance(time.Second)
+		newModel, cmd := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+
+		if cmd != nil || m.ticking {
+			t.Error("実行中のタイマーがなければtickを予約しないべき")
+		}
+
+		// 再開すると新しくtickを予約する
+		if _, cmd = sendTimerListKey(m, runeKey("s")); cmd == nil {
+			t.Error("再開時にtickが予約されるべき")
+		}
+	})
+
+	t.Run("終了したタイマーの名前を通知", func(t *testing.T) {
+		m, clock := newTimerListTestModel()
+		m.timers[0].name = "お茶"
+		m, _ = sendTimerListKey(m, runeKey("m"))
+		m, _ = sendTimerListKey(m, runeKey("s"))
+
+		clock.Advance(6 * time.Minute)
+		newModel, cmd := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+
+		msgs := collectMsgs(cmd)
+		if len(msgs) != 1 {
+			t.Fatalf("終了メッセージのみが送られるべき、実際: %v", msgs)
+		}
+		if s := msgs[0].(timerFinishedMsg).String(); !strings.Contains(s, "「お茶」カウントダウン") {
+			t.Errorf("通知にタイマー名が含まれるべき、実際: %s", s)
+		}
+		if m.ticking {
+			t.Error("終了後はtickを予約しないべき")
+		}
+	})
+
+	t.Run("一覧の表示", func(t *testing.T) {
+		m, clock := newTimerListTestModel()
+		m = addNamedTimer(m, "読書")
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		clock.Advance(90 * time.Second)
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+
+		view := m.View()
+		for _, s := range []string{"タイマー1", "読書", "01:30.0", "実行中", "停止中"} {
+			if !strings.Contains(view, s) {
+				t.Errorf("一覧に %s が含まれるべき", s)
+			}
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 一時停止していた区間
+type timerPause struct {
+	start time.Time
+	end   time.Time
+}
+
+// 計測したセッション（スタートからリセット・終了まで）
+type timerSession struct {
+	label   string
+	mode    timerMode
+	start   time.Time
+	end     time.Time
+	elapsed time.Duration // 一時停止を除いた計測時間
+	pauses  []timerPause
+}
+
+// セッションの記録先を抽象化するインターフェース
+type timerLog interface {
+	// Append - セッションを1件追記する
+	Append(s timerSession) error
+	// Load - 記録済みのセッションを古い順に読み込む（未記録の場合は空）
+	Load() ([]timerSession, error)
+}
+
+// ログ上のモード名
+var timerModeKeys = map[timerMode]string{
+	modeStopwatch: "stopwatch",
+	modeCountdown: "countdown",
+	modePomodoro:  "pomodoro",
+}
+
+// ログ上の一時停止の表現
+type timerPauseRecord struct {
+	Start string `json:"start"` // RFC 3339
+	End   string `json:"end"`   // RFC 3339
+}
+
+// ログ上のセッションの表現（1行に1件）
+type timerSessionRecord struct {
+	Label     string             `json:"label"`
+	Mode      string             `json:"mode"`
+	Start     string             `json:"start"` // RFC 3339
+	End       string             `json:"end"`   // RFC 3339
+	ElapsedMS int64              `json:"elapsed_ms"`
+	Pauses    []timerPauseRecord `json:"pauses,omitempty"`
+}
+
+// セッションをログ上の表現に変換
+func newTimerSessionRecord(s timerSession) timerSessionRecord {
+	r := timerSessionRecord{
+		Label:     s.label,
+		Mode:      timerModeKeys[s.mode],
+		Start:     s.start.Format(time.RFC3339),
+		End:       s.end.Format(time.RFC3339),
+		ElapsedMS: s.elapsed.Milliseconds(),
+	}
+	for _, p := range s.pauses {
+		r.Pauses = append(r.Pauses, timerPauseRecord{
+			Start: p.start.Format(time.RFC3339),
+			End:   p.end.Format(time.RFC3339),
+		})
+	}
+	return r
+}
+
+// ログ上の表現からセッションに変換
+func (r timerSessionRecord) toSession() (timerSession, error) {
+	s := timerSession{
+		label:   r.Label,
+		mode:    lookupKey(timerModeKeys, r.Mode),
+		elapsed: time.Duration(r.ElapsedMS) * time.Millisecond,
+	}
+
+	var err error
+	if s.start, err = time.Parse(time.RFC3339, r.Start); err != nil {
+		return s, fmt.Errorf("開始時刻が不正です: %w", err)
+	}
+	if s.end, err = time.Parse(time.RFC3339, r.End); err != nil {
+		return s, fmt.Errorf("終了時刻が不正です: %w", err)
+	}
+	for _, p := range r.Pauses {
+		var pause timerPause
+		if pause.start, err = time.Parse(time.RFC3339, p.Start); err != nil {
+			return s, fmt.Errorf("一時停止の時刻が不正です: %w", err)
+		}
+		if pause.end, err = time.Parse(time.RFC3339, p.End); err != nil {
+			return s, fmt.Errorf("一時停止の時刻が不正です: %w", err)
+		}
+		s.pauses = append(s.pauses, pause)
+	}
+	return s, nil
+}
+
+// JSON Lines 形式の追記専用ログファイル
+type fileTimerLog struct {
+	path string
+}
+
+// データディレクトリのログファイル
+func defaultTimerLog() (fileTimerLog, error) {
+	path, err := storage.DataPath(constants.TimerLogFileName)
+	if err != nil {
+		return fileTimerLog{}, err
+	}
+	return fileTimerLog{path: path}, nil
+}
+
+// Append - ログファイルの末尾に1行追記
+func (l fileTimerLog) Append(s timerSession) error {
+	return storage.AppendJSONLine(l.path, newTimerSessionRecord(s))
+}
+
+// Load - ログファイルを1行ずつ読み込む
+func (l fileTimerLog) Load() ([]timerSession, error) {
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []timerSession
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var r timerSessionRecord
+		if err := json.Unmarshal(text, &r); err != nil {
+			return nil, fmt.Errorf("JSONパースエラー (%s:%d): %w", l.path, line, err)
+		}
+		s, err := r.toSession()
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", l.path, line, err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, scanner.Err()
+}
+
+// 計測中のセッションを閉じてログに記録する
+// 計測時間のないセッションとポモドーロの休憩は記録しない
+func (m timerModel) recordSession() timerModel {
+	if m.sessionStart.IsZero() {
+		return m
+	}
+	now := m.clock.Now()
+	end := now
+	elapsed := m.duration
+	if m.state == running {
+		elapsed = now.Sub(m.startTime) + m.pausedTime
+		// 区間が終わった後に記録する場合（tickの遅れ・閉じている間の終了）は終わった時刻で区切る
+		if deadline := m.deadline(); !deadline.IsZero() && deadline.Before(now) {
+			end = deadline
+		}
+	}
+	if limit := m.limit(); limit > 0 {
+		elapsed = min(elapsed, limit)
+	}
+	pauses := m.pauses
+	if n := len(pauses); n > 0 && pauses[n-1].end.IsZero() {
+		// 一時停止のまま終えた場合は終了時刻で閉じる
+		pauses = append(pauses[:n-1:n-1], timerPause{start: pauses[n-1].start, end: now})
+	}
+	session := timerSession{
+		label:   m.label(),
+		mode:    m.mode,
+		start:   m.sessionStart,
+		end:     end,
+		elapsed: elapsed,
+		pauses:  pauses,
+	}
+
+	m.sessionStart = time.Time{}
+	m.pauses = nil
+	if m.log == nil || session.elapsed <= 0 || (m.mode == modePomodoro && m.phase != phaseWork) {
+		return m
+	}
+	if err := m.log.Append(session); err != nil {
+		m.err = fmt.Errorf("ログの記録に失敗: %w", err)
+	}
+	return m
+}
+
+// ログ上のラベル（名前のないタイマーはモード名）
+func (m timerModel) label() string {
+	if m.name != "" {
+		return m.name
+	}
+	return m.mode.String()
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用のメモリ上のログ
+type memoryTimerLog struct {
+	sessions []timerSession
+}
+
+func (l *memoryTimerLog) Append(s timerSession) error {
+	l.sessions = append(l.sessions, s)
+	return nil
+}
+
+func (l *memoryTimerLog) Load() ([]timerSession, error) {
+	return l.sessions, nil
+}
+
+// ログに記録するテスト用のタイマー
+func newLoggedTimer() (timerModel, *fakeClock, *memoryTimerLog) {
+	m, clock := newFakeClockTimer()
+	log := &memoryTimerLog{}
+	m.log = log
+	m.name = "読書"
+	return m, clock, log
+}
+
+func TestTimerSessionLog(t *testing.T) {
+	t.Run("リセットでセッションを記録", func(t *testing.T) {
+		m, clock, log := newLoggedTimer()
+		start := clock.Now()
+
+		m, _ = m.handleStartStop()
+		clock.Advance(10 * time.Minute)
+		m, _ = m.handleStartStop() // 一時停止
+		clock.Advance(5 * time.Minute)
+		m, _ = m.handleStartStop() // 再開
+		clock.Advance(20 * time.Minute)
+		m = m.handleReset()
+
+		if len(log.sessions) != 1 {
+			t.Fatalf("セッションが1件記録されるべき、実際: %d", len(log.sessions))
+		}
+		s := log.sessions[0]
+		if s.label != "読書" || s.mode != modeStopwatch {
+			t.Errorf("ラベル・モードが正しくない: %+v", s)
+		}
+		if !s.start.Equal(start) || !s.end.Equal(start.Add(35*time.Minute)) {
+			t.Errorf("開始・終了時刻が正しくない: %v - %v", s.start, s.end)
+		}
+		if s.elapsed != 30*time.Minute {
+			t.Errorf("一時停止を除いた時間は30分であるべき、実際: %v", s.elapsed)
+		}
+		if len(s.pauses) != 1 || s.pauses[0].end.Sub(s.pauses[0].start) != 5*time.Minute {
+			t.Errorf("一時停止が記録されるべき、実際: %+v", s.pauses)
+		}
+
+		m = m.handleReset()
+		if len(log.sessions) != 1 {
+			t.Error("リセット済みのタイマーは二重に記録しないべき")
+		}
+	})
+
+	t.Run("一時停止のままリセットすると一時停止を終了時刻で閉じる", func(t *testing.T) {
+		m, clock, log := newLoggedTimer()
+
+		m, _ = m.handleStartStop()
+		clock.Advance(time.Minute)
+		m, _ = m.handleStartStop()
+		clock.Advance(time.Minute)
+		m.handleReset()
+
+		s := log.sessions[0]
+		if s.elapsed != time.Minute || !s.pauses[0].end.Equal(s.end) {
+			t.Errorf("一時停止は終了時刻までであるべき: %+v", s)
+		}
+	})
+
+	t.Run("計測していないタイマーは記録しない", func(t *testing.T) {
+		m, _, log := newLoggedTimer()
+
+		m = m.handleReset()
+		m, _ = m.handleStartStop()
+		m.handleReset()
+
+		if len(log.sessions) != 0 {
+			t.Errorf("時間のないセッションは記録しないべき、実際: %d", len(log.sessions))
+		}
+	})
+
+	t.Run("カウントダウンの終了で記録", func(t *testing.T) {
+		m, clock, log := newLoggedTimer()
+		m = m.cycleMode()
+		m, _ = m.handleStartStop()
+
+		clock.Advance(5*time.Minute + 300*time.Millisecond)
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		newModel.(timerModel).handleReset()
+
+		if len(log.sessions) != 1 || log.sessions[0].elapsed != 5*time.Minute {
+			t.Errorf("目標時間のセッションが1件記録されるべき、実際: %+v", log.sessions)
+		}
+	})
+
+	t.Run("ポモドーロの休憩は記録しない", func(t *testing.T) {
+		m, clock, log := newLoggedTimer()
+		m = m.cycleMode().cycleMode()
+		m, _ = m.handleStartStop()
+		clock.Advance(10 * time.Minute)
+		m = m.nextPhase() // 作業を途中で切り上げ
+
+		m, _ = m.handleStartStop()
+		clock.Advance(5 * time.Minute)
+		m.nextPhase()
+
+		if len(log.sessions) != 1 || log.sessions[0].elapsed != 10*time.Minute {
+			t.Errorf("作業のみ記録されるべき、実際: %+v", log.sessions)
+		}
+	})
+
+	t.Run("終了時に計測中のセッションを記録", func(t *testing.T) {
+		m, clock, log := newLoggedTimer()
+		m, _ = m.handleStartStop()
+		clock.Advance(time.Minute)
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+		if cmd == nil || len(log.sessions) != 1 {
+			t.Error("終了前にセッションが記録されるべき")
+		}
+	})
+}
+
+func TestFileTimerLog(t *testing.T) {
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+	sessions := []timerSession{
+		{
+			label:   "読書",
+			mode:    modeStopwatch,
+			start:   start,
+			end:     start.Add(40 * time.Minute),
+			elapsed: 30 * time.Minute,
+			pauses:  []timerPause{{start: start.Add(10 * time.Minute), end: start.Add(20 * time.Minute)}},
+		},
+		{label: "会議", mode: modePomodoro, start: start.Add(time.Hour), end: start.Add(90 * time.Minute), elapsed: 25 * time.Minute},
+	}
+
+	t.Run("追記して読み込み", func(t *testing.T) {
+		log := fileTimerLog{path: filepath.Join(t.TempDir(), "sub", "timer_log.jsonl")}
+		for _, s := range sessions {
+			if err := log.Append(s); err != nil {
+				t.Fatalf("追記に失敗: %v", err)
+			}
+		}
+
+		loaded, err := log.Load()
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if len(loaded) != 2 {
+			t.Fatalf("2件読み込まれるべき、実際: %d", len(loaded))
+		}
+		s := loaded[0]
+		if s.label != "読書" || s.elapsed != 30*time.Minute || !s.start.Equal(start) || len(s.pauses) != 1 {
+			t.Errorf("1件目が正しくない: %+v", s)
+		}
+		if loaded[1].mode != modePomodoro {
+			t.Errorf("モードが復元されるべき、実際: %v", loaded[1].mode)
+		}
+
+		data, _ := os.ReadFile(log.path)
+		if lines := strings.Count(string(data), "\n"); lines != 2 {
+			t.Errorf("1件1行で追記されるべき、実際: %d行", lines)
+		}
+	})
+
+	t.Run("ファイルがない場合は空", func(t *testing.T) {
+		log := fileTimerLog{path: filepath.Join(t.TempDir(), "none.jsonl")}
+
+		loaded, err := log.Load()
+
+		if err != nil || len(loaded) != 0 {
+			t.Errorf("空で読み込まれるべき: %v, %v", loaded, err)
+		}
+	})
+
+	t.Run("壊れた行は行番号付きのエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "timer_log.jsonl")
+		log := fileTimerLog{path: path}
+		log.Append(sessions[0])
+		f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		f.WriteString("{broken\n")
+		f.Close()
+
+		_, err := log.Load()
+
+		if err == nil || !strings.Contains(err.Error(), ":2") {
+			t.Errorf("2行目のエラーになるべき、実際: %v", err)
+		}
+	})
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// タイマーのモード
+type timerMode int
+
+const (
+	modeStopwatch timerMode = iota // 経過時間を計る
+	modeCountdown                  // 目標時間から減らす
+	modePomodoro                   // 作業・休憩を繰り返す
+	timerModeCount
+)
+
+// モードの表示名
+var timerModeNames = map[timerMode]string{
+	modeStopwatch: "ストップウォッチ",
+	modeCountdown: "カウントダウン",
+	modePomodoro:  "ポモドーロ",
+}
+
+// String - 表示名
+func (mode timerMode) String() string {
+	return timerModeNames[mode]
+}
+
+// 次のモード
+func (mode timerMode) next() timerMode {
+	return (mode + 1) % timerModeCount
+}
+
+// ポモドーロの区間
+type pomodoroPhase int
+
+const (
+	phaseWork       pomodoroPhase = iota // 作業
+	phaseShortBreak                      // 短い休憩
+	phaseLongBreak                       // 長い休憩
+)
+
+// 区間の表示名
+var pomodoroPhaseNames = map[pomodoroPhase]string{
+	phaseWork:       "作業",
+	phaseShortBreak: "短い休憩",
+	phaseLongBreak:  "長い休憩",
+}
+
+// String - 表示名
+func (p pomodoroPhase) String() string {
+	return pomodoroPhaseNames[p]
+}
+
+// ポモドーロの各区間の長さ
+type pomodoroConfig struct {
+	work           time.Duration
+	shortBreak     time.Duration
+	longBreak      time.Duration
+	longBreakEvery int // 長い休憩までの作業回数
+}
+
+// デフォルトの設定（25分作業・5分休憩・4回ごとに15分休憩）
+func defaultPomodoroConfig() pomodoroConfig {
+	return pomodoroConfig{
+		work:           constants.PomodoroWork,
+		shortBreak:     constants.PomodoroShortBreak,
+		longBreak:      constants.PomodoroLongBreak,
+		longBreakEvery: constants.PomodoroLongBreakEvery,
+	}
+}
+
+// 区間の長さ
+func (c pomodoroConfig) length(p pomodoroPhase) time.Duration {
+	switch p {
+	case phaseShortBreak:
+		return c.shortBreak
+	case phaseLongBreak:
+		return c.longBreak
+	default:
+		return c.work
+	}
+}
+
+// カウントダウン・ポモドーロの区間が終わった時のメッセージ
+// ダッシュボードなど他のモデルも受け取って反応できる
+type timerFinishedMsg struct {
+	name   string // 終わったタイマーの名前
+	mode   timerMode
+	phase  pomodoroPhase // ポモドーロの場合に終わった区間
+	length time.Duration // 終わった区間の長さ
+}
+
+// String - 通知用の文言
+func (msg timerFinishedMsg) String() string {
+	var name string
+	if msg.name != "" {
+		name = "「" + msg.name + "」"
+	}
+	if msg.mode == modePomodoro {
+		return fmt.Sprintf("🍅 %s%s（%s）が終わりました", name, msg.phase, formatDuration(msg.length))
+	}
+	return fmt.Sprintf("⏰ %sカウントダウン（%s）が終わりました", name, formatDuration(msg.length))
+}
+
+// 時間入力用のtextinputを生成
+func newTimerInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.CharLimit = 30
+	ti.Width = 30
+	return ti
+}
+
+// 現在の区間の長さ（ストップウォッチは0で上限なし）
+func (m timerModel) limit() time.Duration {
+	switch m.mode {
+	case modeCountdown:
+		return m.target
+	case modePomodoro:
+		return m.pomodoro.length(m.phase)
+	}
+	return 0
+}
+
+// 残り時間
+func (m timerModel) remaining() time.Duration {
+	return max(m.limit()-m.duration, 0)
+}
+
+// 実行中の区間が終わる時刻（実行中でないか上限がない場合はゼロ値）
+func (m timerModel) deadline() time.Time {
+	limit := m.limit()
+	if m.state != running || limit <= 0 {
+		return time.Time{}
+	}
+	return m.startTime.Add(limit - m.pausedTime)
+}
+
+// 区間の終了処理（終了メッセージの送信とベル）
+func (m timerModel) finish() (timerModel, tea.Cmd) {
+	msg := timerFinishedMsg{name: m.name, mode: m.mode, phase: m.phase, length: m.limit()}
+	m = m.recordSession()
+	m.state = stopped
+	m.pausedTime = 0
+	m.duration = m.limit()
+	if m.mode == modePomodoro {
+		m = m.nextPhase()
+	}
+	return m, tea.Batch(
+		bellCmd(m.bell),
+		func() tea.Msg { return msg },
+	)
+}
+
+// ポモドーロの次の区間へ進む（停止状態で待つ）
+func (m timerModel) nextPhase() timerModel {
+	m = m.recordSession()
+	if m.phase == phaseWork {
+		m.completedWork++
+		m.phase = phaseShortBreak
+		if m.pomodoro.longBreakEvery > 0 && m.completedWork%m.pomodoro.longBreakEvery == 0 {
+			m.phase = phaseLongBreak
+		}
+	} else {
+		m.phase = phaseWork
+	}
+	m.state = stopped
+	m.duration = 0
+	m.pausedTime = 0
+	return m
+}
+
+// 端末のベルを鳴らすコマンド（w には main で tea.WithOutput に渡したのと同じ出力先を渡す）
+// BELはカーソルを動かさない制御文字で、*os.File への Write は1回ごとに排他されるため
+// レンダラーが同じ出力に書き込む画面の途中に混ざって表示を崩すことはない
+// （tea.Printf は画面の上に行を追加してしまうのでベルには使わない）
+func bellCmd(w io.Writer) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		w.Write([]byte("\a"))
+		return nil
+	}
+}
+
+// モードを切り替え（計測中の時間とラップはリセット）
+func (m timerModel) cycleMode() timerModel {
+	m = m.recordSession()
+	m.mode = m.mode.next()
+	m.phase = phaseWork
+	m.completedWork = 0
+	return m.handleReset()
+}
+
+// ラップを記録（実行中のみ）
+// 経過時間はtickを待たずにキーを押した時刻で計る（区間が終わっていた場合は終了処理のコマンドを返す）
+func (m timerModel) recordLap() (timerModel, tea.Cmd) {
+	m, cmd := m.advance()
+	if m.state == running {
+		m.laps = append(m.laps, m.duration)
+	}
+	return m, cmd
+}
+
+// 時間の入力を開始
+func (m timerModel) startEditing() (timerModel, tea.Cmd) {
+	switch m.mode {
+	case modeCountdown:
+		m.input.Placeholder = "例: 5m, 90s, 1:30（数字のみは分）"
+		m.input.SetValue(formatTimerInput(m.target))
+	case modePomodoro:
+		m.input.Placeholder = "作業 短い休憩 長い休憩（例: 25m 5m 15m）"
+		m.input.SetValue(strings.Join([]string{
+			formatTimerInput(m.pomodoro.work),
+			formatTimerInput(m.pomodoro.shortBreak),
+			formatTimerInput(m.pomodoro.longBreak),
+		}, " "))
+	default:
+		// ストップウォッチには設定する時間がない
+		return m, nil
+	}
+	m.editing = true
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+// 時間入力中のメッセージ処理
+func (m timerModel) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m.commitInput(), nil
+		case tea.KeyEsc:
+			m.err = nil
+			return m.endEditing(), nil
+		case tea.KeyCtrlC:
+			return m.recordSession(), tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// 入力した時間を反映（エラーの場合は入力を続ける）
+func (m timerModel) commitInput() timerModel {
+	fields := strings.Fields(m.input.Value())
+	switch m.mode {
+	case modeCountdown:
+		if len(fields) != 1 {
+			m.err = errors.New("時間を1つ入力してください")
+			return m
+		}
+		d, err := parseTimerDuration(fields[0])
+		if err != nil {
+			m.err = err
+			return m
+		}
+		m.target = d
+	case modePomodoro:
+		if len(fields) != 3 {
+			m.err = errors.New("作業・短い休憩・長い休憩の3つを入力してください")
+			return m
+		}
+		var lengths [3]time.Duration
+		for i, f := range fields {
+			d, err := parseTimerDuration(f)
+			if err != nil {
+				m.err = err
+				return m
+			}
+			lengths[i] = d
+		}
+		m.pomodoro.work, m.pomodoro.shortBreak, m.pomodoro.longBreak = lengths[0], lengths[1], lengths[2]
+	}
+	m.err = nil
+	return m.endEditing().handleReset()
+}
+
+// 入力を終了
+func (m timerModel) endEditing() timerModel {
+	m.editing = false
+	m.input.Blur()
+	return m
+}
+
+// parseTimerDuration - "5m" / "90s" / "1h30m" / "1:30" / "1:00:00" / "25"（分）を解釈
+func parseTimerDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	switch {
+	case strings.Contains(s, ":"):
+		// [時:]分:秒
+		parts := strings.Split(s, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("時間の形式が不正です: %s", s)
+		}
+		for _, p := range parts {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("時間の形式が不正です: %s", s)
+			}
+			d = d*60 + time.Duration(n)
+		}
+		d *= time.Second
+	default:
+		if n, err := strconv.Atoi(s); err == nil {
+			d = time.Duration(n) * time.Minute
+			break
+		}
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("時間の形式が不正です: %s", s)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("時間は0より大きくしてください: %s", s)
+	}
+	return d, nil
+}
+
+// 入力欄の初期値（"25m0s" ではなく "25m" のように短く）
+func formatTimerInput(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// ラップの表（新しい順に最大 TimerMaxLapRows 行）
+func (m timerModel) lapTable() string {
+	if len(m.laps) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%3s  %-8s  %-8s", "#", "ラップ", "スプリット"))
+	for i := len(m.laps) - 1; i >= 0 && i >= len(m.laps)-constants.TimerMaxLapRows; i-- {
+		lap := m.laps[i]
+		if i > 0 {
+			lap -= m.laps[i-1]
+		}
+		b.WriteString(fmt.Sprintf("\n%3d  %-8s  %-8s", i+1, formatDuration(lap), formatDuration(m.laps[i])))
+	}
+	return b.String()
+}
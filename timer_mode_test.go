@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ベルを鳴らさないテスト用のタイマー
+func newTimerTestModel(mode timerMode) timerModel {
+	m, _ := newFakeClockTimer()
+	for m.mode != mode {
+		m = m.cycleMode()
+	}
+	return m
+}
+
+// 指定した時間だけ前から実行中の状態にする
+func runningFor(m timerModel, elapsed time.Duration) timerModel {
+	m.state = running
+	m.startTime = m.clock.Now().Add(-elapsed)
+	return m
+}
+
+// コマンドを実行してメッセージを集める（tea.Batch も展開する）
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collectMsgs(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+func TestParseTimerDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"25", 25 * time.Minute},
+		{"90s", 90 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{"1:30", 90 * time.Second},
+		{"1:00:00", time.Hour},
+		{" 5m ", 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := parseTimerDuration(tt.input)
+			if err != nil {
+				t.Fatalf("エラーが発生すべきでない: %v", err)
+			}
+			if d != tt.expected {
+				t.Errorf("parseTimerDuration(%q) = %v, 期待値: %v", tt.input, d, tt.expected)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "abc", "0", "-5m", "1:2:3:4", "1:x"} {
+		t.Run("不正な入力/"+input, func(t *testing.T) {
+			if _, err := parseTimerDuration(input); err == nil {
+				t.Errorf("parseTimerDuration(%q) はエラーになるべき", input)
+			}
+		})
+	}
+}
+
+func TestFormatTimerInput(t *testing.T) {
+	tests := map[time.Duration]string{
+		25 * time.Minute: "25m",
+		10 * time.Minute: "10m",
+		50 * time.Second: "50s",
+		90 * time.Second: "1m30s",
+		time.Hour:        "1h",
+	}
+	for d, expected := range tests {
+		if s := formatTimerInput(d); s != expected {
+			t.Errorf("formatTimerInput(%v) = %q, 期待値: %q", d, s, expected)
+		}
+	}
+}
+
+func TestTimerModes(t *testing.T) {
+	t.Run("mキーでモードを切り替え、リセットされる", func(t *testing.T) {
+		m, _ := newFakeClockTimer()
+		m = runningFor(m, time.Second)
+		m.laps = []time.Duration{time.Second}
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+		m = newModel.(timerModel)
+
+		if m.mode != modeCountdown {
+			t.Errorf("カウントダウンモードになるべき、実際: %v", m.mode)
+		}
+		if m.state != stopped || m.laps != nil {
+			t.Error("モード切り替えでリセットされるべき")
+		}
+	})
+
+	t.Run("カウントダウンは残り時間を表示", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+		m.duration = time.Minute
+
+		view := m.View()
+		if !strings.Contains(view, "04:00.0") {
+			t.Error("5分から1分経過した残り時間が表示されるべき")
+		}
+		if !strings.Contains(view, "カウントダウン") {
+			t.Error("モードが表示されるべき")
+		}
+	})
+
+	t.Run("dキーで目標時間を入力", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		m = newModel.(timerModel)
+		if !m.editing {
+			t.Fatal("入力中になるべき")
+		}
+		if m.input.Value() != "5m" {
+			t.Errorf("現在の目標時間が入力されているべき、実際: %q", m.input.Value())
+		}
+
+		m.input.SetValue("90s")
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = newModel.(timerModel)
+
+		if m.editing {
+			t.Error("Enterで入力を終了するべき")
+		}
+		if m.target != 90*time.Second {
+			t.Errorf("目標時間は90秒になるべき、実際: %v", m.target)
+		}
+	})
+
+	t.Run("不正な入力はエラーを表示して入力を続ける", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+		m, _ = m.startEditing()
+		m.input.SetValue("abc")
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = newModel.(timerModel)
+
+		if !m.editing || m.err == nil {
+			t.Error("エラーで入力を続けるべき")
+		}
+		if m.target != 5*time.Minute {
+			t.Error("目標時間は変更されないべき")
+		}
+		if !strings.Contains(m.View(), "時間の形式が不正です") {
+			t.Error("エラーが表示されるべき")
+		}
+	})
+
+	t.Run("入力中のキーはタイマー操作にならない", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+		m, _ = m.startEditing()
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+		m = newModel.(timerModel)
+
+		if !m.editing || !strings.HasSuffix(m.input.Value(), "q") {
+			t.Error("qは入力欄に入力されるべき")
+		}
+	})
+
+	t.Run("ストップウォッチではdキーは無視", func(t *testing.T) {
+		m := newTimerTestModel(modeStopwatch)
+
+		m, cmd := m.startEditing()
+
+		if m.editing || cmd != nil {
+			t.Error("ストップウォッチでは入力しないべき")
+		}
+	})
+
+	t.Run("カウントダウンの終了でメッセージとベル", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+		var bell bytes.Buffer
+		m.bell = &bell
+		m = runningFor(m, 6*time.Minute)
+
+		newModel, cmd := m.Update(tickMsg(m.clock.Now()))
+		m = newModel.(timerModel)
+
+		if m.state != stopped {
+			t.Errorf("終了後は停止するべき、実際: %v", m.state)
+		}
+		if m.remaining() != 0 {
+			t.Errorf("残り時間は0であるべき、実際: %v", m.remaining())
+		}
+
+		msgs := collectMsgs(cmd)
+		if len(msgs) != 1 {
+			t.Fatalf("終了メッセージが1つ送られるべき、実際: %v", msgs)
+		}
+		finished, ok := msgs[0].(timerFinishedMsg)
+		if !ok || finished.mode != modeCountdown || finished.length != 5*time.Minute {
+			t.Errorf("終了メッセージが正しくない: %+v", msgs[0])
+		}
+		if bell.String() != "\a" {
+			t.Errorf("ベルが鳴るべき、実際: %q", bell.String())
+		}
+	})
+
+	t.Run("終了前は次のtickを予約", func(t *testing.T) {
+		m := newTimerTestModel(modeCountdown)
+		m = runningFor(m, time.Minute)
+
+		newModel, cmd := m.Update(tickMsg(m.clock.Now()))
+
+		if newModel.(timerModel).state != running || cmd == nil {
+			t.Error("実行を続けるべき")
+		}
+	})
+}
+
+func TestTimerPomodoro(t *testing.T) {
+	t.Run("作業の後は短い休憩、4回目の後は長い休憩", func(t *testing.T) {
+		m := newTimerTestModel(modePomodoro)
+
+		var phases []pomodoroPhase
+		for i := 0; i < 8; i++ {
+			m = m.nextPhase()
+			phases = append(phases, m.phase)
+		}
+
+		expected := []pomodoroPhase{
+			phaseShortBreak, phaseWork, phaseShortBreak, phaseWork,
+			phaseShortBreak, phaseWork, phaseLongBreak, phaseWork,
+		}
+		for i := range expected {
+			if phases[i] != expected[i] {
+				t.Errorf("%d番目の区間は%vであるべき、実際: %v", i+1, expected[i], phases[i])
+			}
+		}
+		if m.completedWork != 4 {
+			t.Errorf("作業回数は4であるべき、実際: %d", m.completedWork)
+		}
+	})
+
+	t.Run("作業の終了で休憩に進み停止する", func(t *testing.T) {
+		m := newTimerTestModel(modePomodoro)
+		m = runningFor(m, 26*time.Minute)
+
+		newModel, cmd := m.Update(tickMsg(m.clock.Now()))
+		m = newModel.(timerModel)
+
+		if m.phase != phaseShortBreak || m.state != stopped {
+			t.Errorf("短い休憩で停止するべき: phase=%v state=%v", m.phase, m.state)
+		}
+		if m.remaining() != 5*time.Minute {
+			t.Errorf("休憩の残り時間は5分であるべき、実際: %v", m.remaining())
+		}
+		msgs := collectMsgs(cmd)
+		if len(msgs) != 1 || msgs[0].(timerFinishedMsg).phase != phaseWork {
+			t.Errorf("作業の終了メッセージが送られるべき、実際: %v", msgs)
+		}
+	})
+
+	t.Run("dキーで区間の長さを設定", func(t *testing.T) {
+		m := newTimerTestModel(modePomodoro)
+		m, _ = m.startEditing()
+		if m.input.Value() != "25m 5m 15m" {
+			t.Errorf("現在の設定が入力されているべき、実際: %q", m.input.Value())
+		}
+
+		m.input.SetValue("50 10 30")
+		m = m.commitInput()
+
+		if m.pomodoro.work != 50*time.Minute || m.pomodoro.shortBreak != 10*time.Minute || m.pomodoro.longBreak != 30*time.Minute {
+			t.Errorf("設定が反映されるべき、実際: %+v", m.pomodoro)
+		}
+	})
+
+	t.Run("長さが足りない入力はエラー", func(t *testing.T) {
+		m := newTimerTestModel(modePomodoro)
+		m, _ = m.startEditing()
+		m.input.SetValue("50 10")
+
+		m = m.commitInput()
+
+		if m.err == nil || !m.editing {
+			t.Error("3つ揃わない入力はエラーになるべき")
+		}
+	})
+
+	t.Run("nキーで次の区間へ", func(t *testing.T) {
+		m := newTimerTestModel(modePomodoro)
+
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+		m = newModel.(timerModel)
+
+		if m.phase != phaseShortBreak {
+			t.Errorf("短い休憩に進むべき、実際: %v", m.phase)
+		}
+		if cmd != nil {
+			t.Error("スキップでは終了メッセージを送らないべき")
+		}
+		if !strings.Contains(m.View(), "短い休憩") {
+			t.Error("区間が表示されるべき")
+		}
+	})
+}
+
+func TestTimerLaps(t *testing.T) {
+	t.Run("lキーでラップを記録", func(t *testing.T) {
+		m, clock := newFakeClockTimer()
+		m = m.toggle()
+		for _, d := range []time.Duration{10 * time.Second, 15 * time.Second} {
+			// tickを待たずに押した時刻で計る
+			clock.Advance(d)
+			newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+			m = newModel.(timerModel)
+		}
+
+		if len(m.laps) != 2 || m.laps[0] != 10*time.Second || m.laps[1] != 25*time.Second {
+			t.Fatalf("ラップは押した時刻の経過時間であるべき、実際: %v", m.laps)
+		}
+
+		view := m.View()
+		for _, s := range []string{"ラップ", "スプリット", "00:15.0", "00:25.0", "00:10.0"} {
+			if !strings.Contains(view, s) {
+				t.Errorf("ラップの表に %s が含まれるべき", s)
+			}
+		}
+	})
+
+	t.Run("停止中はラップを記録しない", func(t *testing.T) {
+		m := newTimerTestModel(modeStopwatch)
+
+		m, _ = m.recordLap()
+
+		if len(m.laps) != 0 {
+			t.Error("停止中はラップを記録しないべき")
+		}
+	})
+
+	t.Run("表示するラップは新しい順に最大5行", func(t *testing.T) {
+		m := newTimerTestModel(modeStopwatch)
+		for i := 1; i <= 7; i++ {
+			m.laps = append(m.laps, time.Duration(i)*time.Second)
+		}
+
+		lines := strings.Split(m.lapTable(), "\n")
+
+		if len(lines) != 6 {
+			t.Fatalf("見出しと5行であるべき、実際: %d行", len(lines))
+		}
+		if !strings.HasPrefix(strings.TrimSpace(lines[1]), "7") {
+			t.Errorf("最新のラップが先頭であるべき、実際: %q", lines[1])
+		}
+	})
+}
+
+func TestDashboardTimerFinished(t *testing.T) {
+	m := NewDashboardModel()
+
+	newModel, _ := m.Update(timerFinishedMsg{mode: modeCountdown, length: 5 * time.Minute})
+	m = newModel.(dashboardModel)
+
+	if !strings.Contains(m.View(), "カウントダウン（05:00.0）が終わりました") {
+		t.Error("タイマー終了の通知が表示されるべき")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(dashboardModel)
+	if m.notice != "" {
+		t.Error("キー入力で通知が消えるべき")
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 曜日の表示名
+var reportWeekdayNames = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// セッションのラベル・日ごとの集計
+// 日をまたぐセッションは開始した日に数える
+type timerReport struct {
+	days   []time.Time                // 集計する日（各日の0時）
+	labels []string                   // 合計の多い順
+	totals map[string][]time.Duration // ラベルごとの日別の合計（days と同じ順）
+}
+
+// 集計の期間（week の場合は今週の月曜日から7日間、それ以外は今日のみ）
+func timerReportDays(now time.Time, week bool) []time.Time {
+	from, count := startOfDay(now), 1
+	if week {
+		from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+		count = 7
+	}
+	days := make([]time.Time, count)
+	for i := range days {
+		days[i] = from.AddDate(0, 0, i)
+	}
+	return days
+}
+
+// セッションを集計
+func buildTimerReport(sessions []timerSession, days []time.Time) timerReport {
+	r := timerReport{days: days, totals: make(map[string][]time.Duration)}
+	for _, s := range sessions {
+		start := s.start.In(days[0].Location())
+		i := slices.IndexFunc(days, func(day time.Time) bool {
+			return !start.Before(day) && start.Before(day.AddDate(0, 0, 1))
+		})
+		if i < 0 {
+			continue
+		}
+		if r.totals[s.label] == nil {
+			r.totals[s.label] = make([]time.Duration, len(days))
+			r.labels = append(r.labels, s.label)
+		}
+		r.totals[s.label][i] += s.elapsed
+	}
+
+	slices.SortStableFunc(r.labels, func(a, b string) int {
+		return cmp.Or(cmp.Compare(r.labelTotal(b), r.labelTotal(a)), cmp.Compare(a, b))
+	})
+	return r
+}
+
+// ラベルの期間の合計
+func (r timerReport) labelTotal(label string) time.Duration {
+	var total time.Duration
+	for _, d := range r.totals[label] {
+		total += d
+	}
+	return total
+}
+
+// 日の合計（i < 0 の場合は期間全体）
+func (r timerReport) dayTotal(i int) time.Duration {
+	var total time.Duration
+	for _, label := range r.labels {
+		if i < 0 {
+			total += r.labelTotal(label)
+		} else {
+			total += r.totals[label][i]
+		}
+	}
+	return total
+}
+
+// 集計の時間の表示（"1:05" のように時:分、0は "-"）
+func formatReportDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	minutes := int(d.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// 表示幅で右寄せ（全角のラベルでも列が揃うように）
+func padReportCell(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
+
+// 集計の表（ラベル × 日、最後の列と行は合計）
+func (r timerReport) table() string {
+	if len(r.labels) == 0 {
+		return "記録されたセッションはありません"
+	}
+
+	labelWidth := lipgloss.Width("合計")
+	for _, label := range r.labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+	}
+	const cellWidth = 8
+
+	row := func(label string, cells []string) string {
+		var b strings.Builder
+		b.WriteString(label + strings.Repeat(" ", labelWidth-lipgloss.Width(label)))
+		for _, c := range cells {
+			b.WriteString(padReportCell(c, cellWidth))
+		}
+		return strings.TrimRight(b.String(), " ")
+	}
+
+	var header []string
+	for _, day := range r.days {
+		header = append(header, fmt.Sprintf("%d(%s)", day.Day(), reportWeekdayNames[day.Weekday()]))
+	}
+	lines := []string{row("", append(header, "合計"))}
+
+	for _, label := range r.labels {
+		var cells []string
+		for _, d := range r.totals[label] {
+			cells = append(cells, formatReportDuration(d))
+		}
+		lines = append(lines, row(label, append(cells, formatReportDuration(r.labelTotal(label)))))
+	}
+
+	var totals []string
+	for i := range r.days {
+		totals = append(totals, formatReportDuration(r.dayTotal(i)))
+	}
+	lines = append(lines, row("合計", append(totals, formatReportDuration(r.dayTotal(-1)))))
+	return strings.Join(lines, "\n")
+}
+
+// CSVで書き出す（日付・ラベルごとに1行、時間のない組み合わせは省略）
+func (r timerReport) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "label", "seconds", "duration"}); err != nil {
+		return err
+	}
+	for i, day := range r.days {
+		for _, label := range r.labels {
+			d := r.totals[label][i]
+			if d <= 0 {
+				continue
+			}
+			record := []string{
+				day.Format(dueDateLayout),
+				label,
+				strconv.FormatInt(int64(d.Round(time.Second).Seconds()), 10),
+				formatReportDuration(d),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// runTimerReport - `timer report [--week] [--csv]` の処理
+func runTimerReport(args []string, w io.Writer, log timerLog, now time.Time) error {
+	fs := flag.NewFlagSet("timer report", flag.ContinueOnError)
+	fs.SetOutput(w)
+	week := fs.Bool("week", false, "今週（月曜日から）の集計")
+	asCSV := fs.Bool("csv", false, "CSVで出力")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sessions, err := log.Load()
+	if err != nil {
+		return err
+	}
+	report := buildTimerReport(sessions, timerReportDays(now, *week))
+	if *asCSV {
+		return report.writeCSV(w)
+	}
+	_, err = fmt.Fprintln(w, report.table())
+	return err
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 2026-10-14（水）15:00
+var timerReportNow = time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+
+// 日時を指定したセッション
+func reportSession(label string, day, hour int, elapsed time.Duration) timerSession {
+	start := time.Date(2026, 10, day, hour, 0, 0, 0, time.Local)
+	return timerSession{label: label, start: start, end: start.Add(elapsed), elapsed: elapsed}
+}
+
+func newTimerReportLog() *memoryTimerLog {
+	return &memoryTimerLog{sessions: []timerSession{
+		reportSession("読書", 12, 9, 30*time.Minute),  // 月
+		reportSession("会議", 14, 10, 90*time.Minute), // 水
+		reportSession("読書", 14, 20, 45*time.Minute), // 水
+		reportSession("読書", 14, 23, 20*time.Minute), // 水（日をまたぐ）
+		reportSession("運動", 11, 9, time.Hour),       // 先週の日曜
+		reportSession("運動", 19, 9, time.Hour),       // 来週の月曜
+	}}
+}
+
+func TestTimerReportDays(t *testing.T) {
+	days := timerReportDays(timerReportNow, true)
+	if len(days) != 7 || days[0].Day() != 12 || days[0].Weekday() != time.Monday || days[6].Day() != 18 {
+		t.Errorf("今週は月曜日から7日間であるべき、実際: %v", days)
+	}
+
+	days = timerReportDays(timerReportNow, false)
+	if len(days) != 1 || !days[0].Equal(startOfDay(timerReportNow)) {
+		t.Errorf("今日のみであるべき、実際: %v", days)
+	}
+}
+
+func TestBuildTimerReport(t *testing.T) {
+	r := buildTimerReport(newTimerReportLog().sessions, timerReportDays(timerReportNow, true))
+
+	if strings.Join(r.labels, ",") != "読書,会議" {
+		t.Errorf("期間内のラベルが合計の多い順に並ぶべき、実際: %v", r.labels)
+	}
+	if r.totals["読書"][0] != 30*time.Minute || r.totals["読書"][2] != 65*time.Minute {
+		t.Errorf("読書の日別の合計が正しくない: %v", r.totals["読書"])
+	}
+	if r.dayTotal(2) != 155*time.Minute || r.dayTotal(-1) != 185*time.Minute {
+		t.Errorf("合計が正しくない: 水 %v, 全体 %v", r.dayTotal(2), r.dayTotal(-1))
+	}
+
+	table := r.table()
+	for _, s := range []string{"12(月)", "14(水)", "合計", "0:30", "1:05", "1:35", "2:35", "3:05"} {
+		if !strings.Contains(table, s) {
+			t.Errorf("表に %s が含まれるべき\n%s", s, table)
+		}
+	}
+	if strings.Contains(table, "運動") {
+		t.Error("期間外のセッションは含まれないべき")
+	}
+}
+
+func TestTimerReportCSV(t *testing.T) {
+	r := buildTimerReport(newTimerReportLog().sessions, timerReportDays(timerReportNow, true))
+
+	var buf bytes.Buffer
+	if err := r.writeCSV(&buf); err != nil {
+		t.Fatalf("書き出しに失敗: %v", err)
+	}
+
+	expected := "date,label,seconds,duration\n" +
+		"2026-10-12,読書,1800,0:30\n" +
+		"2026-10-14,読書,3900,1:05\n" +
+		"2026-10-14,会議,5400,1:30\n"
+	if buf.String() != expected {
+		t.Errorf("CSVが正しくない:\n%s\n期待値:\n%s", buf.String(), expected)
+	}
+}
+
+func TestRunTimerReport(t *testing.T) {
+	t.Run("--week で今週の表", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := runTimerReport([]string{"--week"}, &buf, newTimerReportLog(), timerReportNow); err != nil {
+			t.Fatalf("エラーが発生すべきでない: %v", err)
+		}
+		if !strings.Contains(buf.String(), "12(月)") || !strings.Contains(buf.String(), "18(日)") {
+			t.Errorf("今週の表が出力されるべき:\n%s", buf.String())
+		}
+	})
+
+	t.Run("指定なしは今日のみ", func(t *testing.T) {
+		var buf bytes.Buffer
+		runTimerReport(nil, &buf, newTimerReportLog(), timerReportNow)
+		if strings.Contains(buf.String(), "12(月)") || !strings.Contains(buf.String(), "14(水)") {
+			t.Errorf("今日の表が出力されるべき:\n%s", buf.String())
+		}
+	})
+
+	t.Run("--csv でCSV出力", func(t *testing.T) {
+		var buf bytes.Buffer
+		runTimerReport([]string{"--week", "--csv"}, &buf, newTimerReportLog(), timerReportNow)
+		if !strings.HasPrefix(buf.String(), "date,label,seconds,duration\n") {
+			t.Errorf("CSVが出力されるべき:\n%s", buf.String())
+		}
+	})
+
+	t.Run("記録がない場合", func(t *testing.T) {
+		var buf bytes.Buffer
+		runTimerReport(nil, &buf, &memoryTimerLog{}, timerReportNow)
+		if !strings.Contains(buf.String(), "記録されたセッションはありません") {
+			t.Errorf("記録がないことを表示するべき:\n%s", buf.String())
+		}
+	})
+}
+
+func TestTimerListReport(t *testing.T) {
+	t.Run("Rキーで今週のレポートを表示", func(t *testing.T) {
+		clock := newFakeClock(timerReportNow)
+		m := newTimerList(clock, nil, newTimerReportLog())
+
+		m, _ = sendTimerListKey(m, runeKey("R"))
+
+		view := m.View()
+		if !strings.Contains(view, "今週のレポート") || !strings.Contains(view, "読書") {
+			t.Errorf("レポートが表示されるべき:\n%s", view)
+		}
+
+		m, _ = sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m.report != "" {
+			t.Error("Escで一覧に戻るべき")
+		}
+	})
+
+	t.Run("ログがない場合はエラー", func(t *testing.T) {
+		m, _ := newTimerListTestModel()
+
+		m, _ = sendTimerListKey(m, runeKey("R"))
+
+		if m.err != errNoTimerLog || m.report != "" {
+			t.Error("記録先がない場合はエラーになるべき")
+		}
+	})
+
+	t.Run("削除・終了で計測中のセッションを記録", func(t *testing.T) {
+		clock := newFakeClock(timerReportNow)
+		log := &memoryTimerLog{}
+		m := newTimerList(clock, nil, log)
+		m = addNamedTimer(m, "読書")
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		m.cursor = 0
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		clock.Advance(time.Minute)
+
+		m, _ = sendTimerListKey(m, runeKey("x"))
+		if len(log.sessions) != 1 || log.sessions[0].label != "タイマー1" {
+			t.Errorf("削除したタイマーのセッションが記録されるべき、実際: %+v", log.sessions)
+		}
+
+		_, cmd := sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyCtrlC})
+		if cmd == nil || len(log.sessions) != 2 || log.sessions[1].label != "読書" {
+			t.Errorf("終了時に残りのセッションが記録されるべき、実際: %+v", log.sessions)
+		}
+	})
+}
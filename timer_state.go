@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 終了時のタイマー一覧の状態を保存し、次の起動時に復元する。
+// 実行中のタイマーは開始時刻を保存するので、閉じている間も進み続けたことになる。
+
+// 閉じている間に区間が終わっていたタイマーを終わった時刻で終了する
+// 終了メッセージは送るが、過ぎた時刻のベルは鳴らさない
+func (m timerModel) finishOverdue() (timerModel, tea.Cmd) {
+	deadline := m.deadline()
+	if deadline.IsZero() || m.clock.Now().Before(deadline) {
+		return m, nil
+	}
+	bell := m.bell
+	m.bell = nil
+	m, cmd := m.finish()
+	m.bell = bell
+	return m, cmd
+}
+
+// 保存・復元するタイマー一覧の状態
+type timerListSnapshot struct {
+	timers []timerModel
+	cursor int
+	added  int
+}
+
+// タイマー一覧の状態の保存先を抽象化するインターフェース
+type timerStateStore interface {
+	// Load - 保存済みの状態を読み込む（未保存の場合は fs.ErrNotExist を返す）
+	Load() (timerListSnapshot, error)
+	// Save - 状態を保存する
+	Save(s timerListSnapshot) error
+}
+
+// ファイル上の状態名
+var timerStateKeys = map[timerState]string{
+	stopped: "stopped",
+	running: "running",
+	paused:  "paused",
+}
+
+// ファイル上の区間名
+var pomodoroPhaseKeys = map[pomodoroPhase]string{
+	phaseWork:       "work",
+	phaseShortBreak: "short_break",
+	phaseLongBreak:  "long_break",
+}
+
+// ファイル上の名前から値を引く（見つからない場合はゼロ値）
+func lookupKey[T comparable](keys map[T]string, name string) T {
+	for v, key := range keys {
+		if key == name {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// JSONファイルのフォーマットバージョン
+const timerStateFileVersion = 1
+
+// JSONファイル上の表現
+type timerStateFile struct {
+	Version int                `json:"version"`
+	Cursor  int                `json:"cursor"`
+	Added   int                `json:"added"`
+	Timers  []timerStateRecord `json:"timers"`
+}
+
+// JSONファイル上のポモドーロの設定
+type pomodoroRecord struct {
+	WorkMS         int64 `json:"work_ms"`
+	ShortBreakMS   int64 `json:"short_break_ms"`
+	LongBreakMS    int64 `json:"long_break_ms"`
+	LongBreakEvery int   `json:"long_break_every"`
+}
+
+// JSONファイル上のタイマー
+type timerStateRecord struct {
+	Name          string             `json:"name"`
+	Mode          string             `json:"mode"`
+	State         string             `json:"state"`
+	StartTime     string             `json:"start_time,omitempty"` // RFC 3339（ナノ秒まで）
+	PausedMS      int64              `json:"paused_ms,omitempty"`
+	DurationMS    int64              `json:"duration_ms,omitempty"`
+	TargetMS      int64              `json:"target_ms"`
+	Pomodoro      pomodoroRecord     `json:"pomodoro"`
+	Phase         string             `json:"phase"`
+	CompletedWork int                `json:"completed_work,omitempty"`
+	LapsMS        []int64            `json:"laps_ms,omitempty"`
+	SessionStart  string             `json:"session_start,omitempty"` // RFC 3339（ナノ秒まで）
+	Pauses        []timerPauseRecord `json:"pauses,omitempty"`
+}
+
+// 時刻をファイル上の表現に変換（ゼロ値は空）
+func formatStateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// ファイル上の表現から時刻に変換（空はゼロ値）
+func parseStateTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// タイマーをJSON上の表現に変換
+func newTimerStateRecord(t timerModel) timerStateRecord {
+	r := timerStateRecord{
+		Name:       t.name,
+		Mode:       timerModeKeys[t.mode],
+		State:      timerStateKeys[t.state],
+		StartTime:  formatStateTime(t.startTime),
+		PausedMS:   t.pausedTime.Milliseconds(),
+		DurationMS: t.duration.Milliseconds(),
+		TargetMS:   t.target.Milliseconds(),
+		Pomodoro: pomodoroRecord{
+			WorkMS:         t.pomodoro.work.Milliseconds(),
+			ShortBreakMS:   t.pomodoro.shortBreak.Milliseconds(),
+			LongBreakMS:    t.pomodoro.longBreak.Milliseconds(),
+			LongBreakEvery: t.pomodoro.longBreakEvery,
+		},
+		Phase:         pomodoroPhaseKeys[t.phase],
+		CompletedWork: t.completedWork,
+		SessionStart:  formatStateTime(t.sessionStart),
+	}
+	for _, lap := range t.laps {
+		r.LapsMS = append(r.LapsMS, lap.Milliseconds())
+	}
+	for _, p := range t.pauses {
+		r.Pauses = append(r.Pauses, timerPauseRecord{Start: formatStateTime(p.start), End: formatStateTime(p.end)})
+	}
+	return r
+}
+
+// JSON上の表現からタイマーに変換（時計などは一覧が設定する）
+func (r timerStateRecord) toTimer() (timerModel, error) {
+	ms := func(n int64) time.Duration { return time.Duration(n) * time.Millisecond }
+
+	t := NewTimerModel()
+	t.name = r.Name
+	t.mode = lookupKey(timerModeKeys, r.Mode)
+	t.state = lookupKey(timerStateKeys, r.State)
+	t.pausedTime = ms(r.PausedMS)
+	t.duration = ms(r.DurationMS)
+	t.target = ms(r.TargetMS)
+	t.pomodoro = pomodoroConfig{
+		work:           ms(r.Pomodoro.WorkMS),
+		shortBreak:     ms(r.Pomodoro.ShortBreakMS),
+		longBreak:      ms(r.Pomodoro.LongBreakMS),
+		longBreakEvery: r.Pomodoro.LongBreakEvery,
+	}
+	t.phase = lookupKey(pomodoroPhaseKeys, r.Phase)
+	t.completedWork = r.CompletedWork
+	for _, lap := range r.LapsMS {
+		t.laps = append(t.laps, ms(lap))
+	}
+
+	var err error
+	if t.startTime, err = parseStateTime(r.StartTime); err != nil {
+		return t, fmt.Errorf("開始時刻が不正です: %w", err)
+	}
+	if t.sessionStart, err = parseStateTime(r.SessionStart); err != nil {
+		return t, fmt.Errorf("セッションの開始時刻が不正です: %w", err)
+	}
+	for _, p := range r.Pauses {
+		var pause timerPause
+		if pause.start, err = parseStateTime(p.Start); err != nil {
+			return t, fmt.Errorf("一時停止の時刻が不正です: %w", err)
+		}
+		if pause.end, err = parseStateTime(p.End); err != nil {
+			return t, fmt.Errorf("一時停止の時刻が不正です: %w", err)
+		}
+		t.pauses = append(t.pauses, pause)
+	}
+	if t.state == running && t.startTime.IsZero() {
+		return t, fmt.Errorf("実行中のタイマー「%s」に開始時刻がありません", r.Name)
+	}
+	return t, nil
+}
+
+// JSONファイルに保存するストア
+type jsonTimerStateStore struct {
+	path string
+}
+
+// データディレクトリの状態ファイル
+func defaultTimerStateStore() (jsonTimerStateStore, error) {
+	path, err := storage.DataPath(constants.TimerStateFileName)
+	if err != nil {
+		return jsonTimerStateStore{}, err
+	}
+	return jsonTimerStateStore{path: path}, nil
+}
+
+// Load - JSONファイルから読み込み
+func (s jsonTimerStateStore) Load() (timerListSnapshot, error) {
+	var file timerStateFile
+	if err := storage.LoadJSON(s.path, &file); err != nil {
+		return timerListSnapshot{}, err
+	}
+	if file.Version > timerStateFileVersion {
+		return timerListSnapshot{}, fmt.Errorf("未対応のファイルバージョンです: %d", file.Version)
+	}
+
+	snapshot := timerListSnapshot{cursor: file.Cursor, added: file.Added}
+	for i, r := range file.Timers {
+		t, err := r.toTimer()
+		if err != nil {
+			return timerListSnapshot{}, fmt.Errorf("%d件目のタイマー: %w", i+1, err)
+		}
+		snapshot.timers = append(snapshot.timers, t)
+	}
+	return snapshot, nil
+}
+
+// Save - JSONファイルに保存
+func (s jsonTimerStateStore) Save(snapshot timerListSnapshot) error {
+	file := timerStateFile{
+		Version: timerStateFileVersion,
+		Cursor:  snapshot.cursor,
+		Added:   snapshot.added,
+	}
+	for _, t := range snapshot.timers {
+		file.Timers = append(file.Timers, newTimerStateRecord(t))
+	}
+	return storage.SaveJSON(s.path, file)
+}
+
+// 状態の読み込みが完了した時のメッセージ
+type timerStateLoadedMsg struct {
+	snapshot timerListSnapshot
+	err      error
+}
+
+// ストアから読み込むコマンド
+func loadTimerStateCmd(store timerStateStore) tea.Cmd {
+	return func() tea.Msg {
+		snapshot, err := store.Load()
+		return timerStateLoadedMsg{snapshot: snapshot, err: err}
+	}
+}
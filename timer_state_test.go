@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用のメモリ上の状態の保存先
+type memoryTimerStateStore struct {
+	snapshot *timerListSnapshot
+	saveErr  error
+}
+
+func (s *memoryTimerStateStore) Load() (timerListSnapshot, error) {
+	if s.snapshot == nil {
+		return timerListSnapshot{}, fs.ErrNotExist
+	}
+	return *s.snapshot, nil
+}
+
+func (s *memoryTimerStateStore) Save(snapshot timerListSnapshot) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.snapshot = &snapshot
+	return nil
+}
+
+// 保存先を指定したテスト用のタイマー一覧
+func newStoredTimerList(clock *fakeClock, log timerLog, store timerStateStore) timerListModel {
+	m := newTimerList(clock, nil, log)
+	m.store = store
+	return m
+}
+
+// Init のコマンドを実行して読み込み結果を反映
+func initTimerList(t *testing.T, m timerListModel) (timerListModel, tea.Cmd) {
+	t.Helper()
+	cmd := m.Init()
+	if cmd == nil {
+		t.Fatal("読み込みコマンドが返されるべき")
+	}
+	newModel, next := m.Update(cmd())
+	return newModel.(timerListModel), next
+}
+
+func TestTimerListPersist(t *testing.T) {
+	t.Run("閉じている間も実行中のタイマーが進む", func(t *testing.T) {
+		clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+		log := &memoryTimerLog{}
+		store := &memoryTimerStateStore{}
+
+		m := newStoredTimerList(clock, log, store)
+		m = addNamedTimer(m, "読書")
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		clock.Advance(10 * time.Minute)
+		m, cmd := sendTimerListKey(m, runeKey("q"))
+
+		if cmd == nil || store.snapshot == nil {
+			t.Fatal("終了時に状態が保存されるべき")
+		}
+		if len(log.sessions) != 0 {
+			t.Error("保存した場合は計測中のセッションを終わらせないべき")
+		}
+
+		// 閉じている間に1時間経過して再起動
+		clock.Advance(time.Hour)
+		m = newStoredTimerList(clock, log, store)
+		m, cmd = initTimerList(t, m)
+
+		if len(m.timers) != 2 || m.cursor != 1 || m.current().name != "読書" {
+			t.Fatalf("タイマーと選択が復元されるべき、実際: %v (%d)", timerNames(m), m.cursor)
+		}
+		if cmd == nil || !m.ticking {
+			t.Fatal("実行中のタイマーがあればtickを予約するべき")
+		}
+
+		newModel, _ := m.Update(tickMsg(clock.Now()))
+		m = newModel.(timerListModel)
+		if d := m.current().duration; d != 70*time.Minute {
+			t.Errorf("保存した開始時刻から計算されるべき、実際: %v", d)
+		}
+
+		// 再起動後にリセットすると閉じていた間も含めて1つのセッションになる
+		m, _ = sendTimerListKey(m, runeKey("r"))
+		if len(log.sessions) != 1 || log.sessions[0].elapsed != 70*time.Minute {
+			t.Errorf("セッションが1件記録されるべき、実際: %+v", log.sessions)
+		}
+	})
+
+	t.Run("一時停止中のタイマーは止まったまま", func(t *testing.T) {
+		clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+		store := &memoryTimerStateStore{}
+
+		m := newStoredTimerList(clock, nil, store)
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		clock.Advance(3 * time.Minute)
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		sendTimerListKey(m, runeKey("q"))
+
+		clock.Advance(time.Hour)
+		m, cmd := initTimerList(t, newStoredTimerList(clock, nil, store))
+
+		if m.current().state != paused || m.current().duration != 3*time.Minute {
+			t.Errorf("一時停止の状態と時間が復元されるべき: %v %v", m.current().state, m.current().duration)
+		}
+		if cmd != nil {
+			t.Error("実行中のタイマーがなければtickを予約しないべき")
+		}
+	})
+
+	t.Run("閉じている間にカウントダウンが終わった場合", func(t *testing.T) {
+		start := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+		clock := newFakeClock(start)
+		log := &memoryTimerLog{}
+		store := &memoryTimerStateStore{}
+
+		m := newStoredTimerList(clock, log, store)
+		m, _ = sendTimerListKey(m, runeKey("m"))
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		sendTimerListKey(m, runeKey("q"))
+		target := m.current().target
+
+		clock.Advance(time.Hour)
+		var bell bytes.Buffer
+		m = newStoredTimerList(clock, log, store)
+		m.bell = &bell
+		m, cmd := initTimerList(t, m)
+
+		if m.current().state != stopped || m.ticking {
+			t.Error("読み込んだ時点で終了するべき")
+		}
+		if msgs := collectMsgs(cmd); len(msgs) != 1 || bell.Len() != 0 {
+			t.Errorf("終了メッセージを送り、過ぎた時刻のベルは鳴らさないべき、実際: %v %q", msgs, bell.String())
+		}
+		if len(log.sessions) != 1 || log.sessions[0].elapsed != target || !log.sessions[0].end.Equal(start.Add(target)) {
+			t.Errorf("終わるはずだった時刻で予定の長さのセッションを記録するべき、実際: %+v", log.sessions)
+		}
+	})
+
+	t.Run("未保存の場合は初期状態", func(t *testing.T) {
+		clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+
+		m, cmd := initTimerList(t, newStoredTimerList(clock, nil, &memoryTimerStateStore{}))
+
+		if len(m.timers) != 1 || m.err != nil || cmd != nil {
+			t.Error("初期状態のまま始めるべき")
+		}
+	})
+
+	t.Run("保存に失敗した場合はセッションを記録", func(t *testing.T) {
+		clock := newFakeClock(time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local))
+		log := &memoryTimerLog{}
+		store := &memoryTimerStateStore{saveErr: errors.New("書き込めません")}
+
+		m := newStoredTimerList(clock, log, store)
+		m, _ = sendTimerListKey(m, runeKey("s"))
+		clock.Advance(time.Minute)
+		m, cmd := sendTimerListKey(m, tea.KeyMsg{Type: tea.KeyCtrlC})
+
+		if len(log.sessions) != 1 {
+			t.Error("計測時間が失われないようにセッションを記録するべき")
+		}
+		if cmd != nil || !strings.Contains(m.View(), "保存できません") {
+			t.Fatalf("黙って終了せずに保存エラーを表示するべき:\n%s", m.View())
+		}
+
+		m, cmd = sendTimerListKey(m, runeKey("q"))
+		if cmd == nil || cmd() != tea.Quit() || len(log.sessions) != 1 {
+			t.Errorf("もう一度終了すると二重に記録せずに終了するべき、実際: %d件", len(log.sessions))
+		}
+	})
+}
+
+func TestJSONTimerStateStore(t *testing.T) {
+	start := time.Date(2026, 10, 14, 15, 0, 0, 123456789, time.Local)
+
+	t.Run("保存と読み込み", func(t *testing.T) {
+		store := jsonTimerStateStore{path: filepath.Join(t.TempDir(), "timer_state.json")}
+
+		reading := NewTimerModel()
+		reading.name = "読書"
+		reading.state = running
+		reading.startTime = start
+		reading.pausedTime = 90 * time.Second
+		reading.sessionStart = start.Add(-time.Hour)
+		reading.pauses = []timerPause{{start: start.Add(-10 * time.Minute), end: start}}
+		reading.laps = []time.Duration{time.Minute, 2 * time.Minute}
+
+		pomodoro := NewTimerModel()
+		pomodoro.name = "作業"
+		pomodoro.mode = modePomodoro
+		pomodoro.state = paused
+		pomodoro.phase = phaseLongBreak
+		pomodoro.completedWork = 4
+		pomodoro.pomodoro.work = 50 * time.Minute
+		pomodoro.pauses = []timerPause{{start: start}}
+
+		err := store.Save(timerListSnapshot{timers: []timerModel{reading, pomodoro}, cursor: 1, added: 3})
+		if err != nil {
+			t.Fatalf("保存に失敗: %v", err)
+		}
+
+		snapshot, err := store.Load()
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if snapshot.cursor != 1 || snapshot.added != 3 || len(snapshot.timers) != 2 {
+			t.Fatalf("一覧の状態が復元されるべき: %+v", snapshot)
+		}
+
+		r := snapshot.timers[0]
+		if r.name != "読書" || r.state != running || !r.startTime.Equal(start) || r.pausedTime != 90*time.Second {
+			t.Errorf("実行中のタイマーが復元されるべき: %+v", r)
+		}
+		if !r.sessionStart.Equal(start.Add(-time.Hour)) || len(r.pauses) != 1 || len(r.laps) != 2 {
+			t.Errorf("セッションとラップが復元されるべき: %+v", r)
+		}
+
+		p := snapshot.timers[1]
+		if p.mode != modePomodoro || p.state != paused || p.phase != phaseLongBreak || p.completedWork != 4 {
+			t.Errorf("ポモドーロの状態が復元されるべき: %+v", p)
+		}
+		if p.pomodoro.work != 50*time.Minute || p.pomodoro.longBreakEvery != 4 {
+			t.Errorf("ポモドーロの設定が復元されるべき: %+v", p.pomodoro)
+		}
+		if !p.pauses[0].end.IsZero() {
+			t.Error("継続中の一時停止は終了時刻なしで復元されるべき")
+		}
+	})
+
+	t.Run("ファイルがない場合", func(t *testing.T) {
+		store := jsonTimerStateStore{path: filepath.Join(t.TempDir(), "none.json")}
+
+		if _, err := store.Load(); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fs.ErrNotExist を返すべき、実際: %v", err)
+		}
+	})
+
+	t.Run("開始時刻のない実行中のタイマーはエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "timer_state.json")
+		os.WriteFile(path, []byte(`{"version":1,"timers":[{"name":"読書","mode":"stopwatch","state":"running"}]}`), 0o644)
+
+		if _, err := (jsonTimerStateStore{path: path}).Load(); err == nil {
+			t.Error("エラーになるべき")
+		}
+	})
+}
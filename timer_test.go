@@ -133,16 +133,17 @@ func TestTimerModel(t *testing.T) {
 	})
 
 	t.Run("tickMsg処理（実行中）", func(t *testing.T) {
-		m := NewTimerModel()
+		m, clock := newFakeClockTimer()
 		m.state = running
-		m.startTime = time.Now().Add(-time.Second * 3) // 3秒前に開始
-		msg := tickMsg(time.Now())
+		m.startTime = clock.Now()
+		clock.Advance(time.Second * 3) // 3秒経過
+		msg := tickMsg(clock.Now())
 
 		newModel, cmd := m.Update(msg)
 		updatedModel := newModel.(timerModel)
 
-		if updatedModel.duration < time.Second*2 {
-			t.Errorf("3秒前に開始したので、duration は少なくとも2秒以上であるべき、実際: %v", updatedModel.duration)
+		if updatedModel.duration != time.Second*3 {
+			t.Errorf("3秒前に開始したので、duration は3秒であるべき、実際: %v", updatedModel.duration)
 		}
 		if cmd == nil {
 			t.Error("次のtickコマンドが返されるべき")
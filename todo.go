@@ -1,25 +1,78 @@
 package main
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"io/fs"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ktny/bubbletea-learning/pkg/styles"
 )
 
 // TODOアイテムの構造体
 type todoItem struct {
-	title     string
-	completed bool
+	title       string
+	completed   bool
+	done        time.Time    // 完了日時（ゼロ値は不明）
+	due         time.Time    // 期日（ゼロ値は期日なし）
+	priority    todoPriority // 優先度
+	rawPriority string       // todo.txt の優先度の文字（(D) 以降の文字をそのまま書き戻すため）
+	tags        []string     // タグ
+	note        string       // 複数行のメモ
+	created     time.Time    // 作成日時
+	depth       int          // 階層の深さ（0がルート）
+	collapsed   bool         // 子孫を折りたたんでいるか
+	leading     []string     // テキスト形式のファイルでアイテムの前にあった項目以外の行（見出し・空行など）
+	marked      bool         // 複数選択で選ばれているか（保存しない）
+}
+
+// 完了状態を変更したアイテム（完了日時も合わせて更新する）
+func (item todoItem) withCompleted(completed bool, now time.Time) todoItem {
+	if item.completed == completed {
+		return item
+	}
+	item.completed = completed
+	item.done = time.Time{}
+	if completed {
+		item.done = now
+	}
+	return item
+}
+
+// 期日が過ぎているか（完了済みは対象外）
+func (item todoItem) overdue(now time.Time) bool {
+	return !item.completed && !item.due.IsZero() && item.due.Before(startOfDay(now))
 }
 
 // TODOリストモデル
 type todoModel struct {
-	items    []todoItem // TODOアイテムのリスト
-	cursor   int        // 現在選択している項目のインデックス
-	viewport int        // ビューポートの開始位置
-	height   int        // 表示可能な行数
+	items      []todoItem       // TODOアイテムのリスト
+	cursor     int              // 現在選択している行（表示順）
+	viewport   int              // ビューポートの開始位置
+	height     int              // 表示可能な行数
+	store      todoStore        // 永続化先（nilの場合は保存しない）
+	err        error            // 直近の読み込み・保存・入力エラー
+	mode       todoMode         // 入力モード
+	input      textinput.Model  // タイトル・期日・タグの入力欄
+	noteInput  textarea.Model   // メモの入力欄
+	prevCursor int              // 追加をキャンセルした時に戻る行
+	addingIdx  int              // 追加中のアイテムのインデックス
+	pendingKey string           // 複数キー操作（d d）の1打目
+	deleted    [][]deletedTodo  // 削除履歴（1回の操作で削除したまとまりごと）
+	now        func() time.Time // 現在時刻（テストで差し替え可能）
+
+	filterInput   textinput.Model // 絞り込みの入力欄
+	hideCompleted bool            // 完了済みを非表示にするか
+	sortMode      todoSortMode    // 並び順
+	visualAnchor  int             // 範囲選択の起点のアイテムのインデックス
+	loading       bool            // ストアから読み込み中か（読み込み前の初期データを保存しないよう操作を受け付けない）
 }
 
 // コンストラクタ
@@ -37,29 +90,89 @@ func NewTodoModel() todoModel {
 	}
 
 	return todoModel{
-		items:    items,
-		cursor:   0,
-		viewport: 0,
-		height:   10, // デフォルトの表示行数
+		items:       items,
+		cursor:      0,
+		viewport:    0,
+		height:      10, // デフォルトの表示行数
+		input:       newTodoInput(),
+		noteInput:   newTodoNoteInput(),
+		filterInput: newTodoFilterInput(),
+		now:         time.Now,
 	}
 }
 
+// 永続化ストア付きのコンストラクタ
+func NewTodoModelWithStore(store todoStore) todoModel {
+	m := NewTodoModel()
+	m.store = store
+	m.loading = true
+	return m
+}
+
 // Init - 初期化
 func (m todoModel) Init() tea.Cmd {
+	if m.store != nil {
+		return loadTodosCmd(m.store)
+	}
 	return nil
 }
 
 // Update - メッセージ処理
 func (m todoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// 読み込みが終わるまでは終了以外のキーを無視
+	if key, ok := msg.(tea.KeyMsg); ok && m.loading {
+		if key.Type == tea.KeyCtrlC || key.String() == "q" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	// 絞り込みの入力中
+	if m.mode == todoFiltering {
+		return m.updateFilter(msg)
+	}
+
+	// 範囲選択中
+	if m.mode == todoVisual {
+		return m.updateVisual(msg)
+	}
+
+	// 入力モード中はtextinputに委譲
+	if m.mode != todoNormal {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m.updateInput(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// d d のような2打鍵操作の1打目を取り出す
+		pendingKey := m.pendingKey
+		m.pendingKey = ""
+
 		switch msg.Type {
 		case tea.KeyUp:
-			m = m.moveCursorUp()
+			if msg.Alt {
+				m = m.moveItemUp().save()
+			} else {
+				m = m.moveCursorUp()
+			}
 		case tea.KeyDown:
-			m = m.moveCursorDown()
+			if msg.Alt {
+				m = m.moveItemDown().save()
+			} else {
+				m = m.moveCursorDown()
+			}
+		case tea.KeyLeft:
+			m = m.collapseOrParent().save()
+		case tea.KeyRight:
+			m = m.expandOrChild().save()
 		case tea.KeyEnter, tea.KeySpace:
-			m = m.toggleItem()
+			if m.hasSelection() {
+				m = m.bulkToggle(false).save()
+			} else {
+				m = m.toggleItem().save()
+			}
 		case tea.KeyRunes:
 			switch string(msg.Runes) {
 			case "q":
@@ -68,22 +181,132 @@ func (m todoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.moveCursorDown()
 			case "k":
 				m = m.moveCursorUp()
+			case "J":
+				m = m.moveItemDown().save()
+			case "K":
+				m = m.moveItemUp().save()
+			case "h":
+				m = m.collapseOrParent().save()
+			case "l":
+				m = m.expandOrChild().save()
+			case "x":
+				if m.hasSelection() {
+					m = m.bulkToggle(true).save()
+				} else {
+					m = m.toggleWithChildren().save()
+				}
+			case "v":
+				m = m.toggleMark()
+			case "V":
+				m = m.startVisual()
+			case "M":
+				m = m.bulkMove().save()
+			case ">":
+				m = m.indentItem().save()
+			case "<":
+				m = m.outdentItem().save()
+			case "a", "o":
+				return m.startAdding()
+			case "A":
+				return m.startAddingChild()
+			case "e":
+				return m.startEditing(todoEditing)
+			case "D":
+				return m.startEditing(todoEditingDue)
+			case "t":
+				return m.startEditing(todoEditingTags)
+			case "n":
+				return m.startEditing(todoEditingNote)
+			case "p":
+				m = m.cyclePriority().save()
+			case "/":
+				return m.startFiltering()
+			case "c":
+				m = m.toggleHideCompleted()
+			case "s":
+				m = m.cycleSortMode().save()
+			case "d":
+				if pendingKey == "d" && m.hasSelection() {
+					m = m.bulkDelete().save()
+				} else if pendingKey == "d" {
+					m = m.deleteItem().save()
+				} else {
+					m.pendingKey = "d"
+				}
+			case "u":
+				m = m.undoDelete().save()
+			}
+		case tea.KeyEsc:
+			// 選択中・絞り込み中はまず解除する
+			if m.hasSelection() {
+				return m.clearSelection(), nil
 			}
-		case tea.KeyCtrlC, tea.KeyEsc:
+			if m.filterInput.Value() != "" {
+				return m.clearFilter(), nil
+			}
+			return m, tea.Quit
+		case tea.KeyCtrlC:
 			return m, tea.Quit
 		}
 
+	case todoLoadedMsg:
+		m = m.handleLoaded(msg)
+
 	case tea.WindowSizeMsg:
 		// ウィンドウサイズの変更に対応
 		m.height = msg.Height - 10 // ヘッダーやフッター分を引く
 		if m.height < 1 {
 			m.height = 1
 		}
+
+	default:
+		// カーソル点滅などtextinput向けのメッセージ
+		if m.mode != todoNormal {
+			return m.updateInput(msg)
+		}
 	}
 
 	return m, nil
 }
 
+// 表示順の比較（優先度の高い順 → 期日の近い順、期日なしは後ろ）
+func compareTodoItems(a, b todoItem) int {
+	return cmp.Or(cmp.Compare(b.priority, a.priority), compareDue(a, b))
+}
+
+// アイテム自身が表示条件に一致するか（絞り込み・完了済みの非表示）
+func (m todoModel) visible(index int) bool {
+	// 追加中のアイテムは条件に関わらず表示する
+	if m.mode == todoAdding && index == m.addingIdx {
+		return true
+	}
+	item := m.items[index]
+	if m.hideCompleted && item.completed {
+		return false
+	}
+	return item.matches(m.filterInput.Value())
+}
+
+// カーソル位置のアイテムのインデックス（アイテムがない場合は-1）
+func (m todoModel) currentIndex() int {
+	rows := m.rows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return -1
+	}
+	return rows[m.cursor]
+}
+
+// 指定したアイテムの行にカーソルを移動
+func (m todoModel) cursorToItem(index int) todoModel {
+	row := slices.Index(m.rows(), index)
+	if row < 0 {
+		// 非表示になった場合は範囲内に収める
+		return m.clampCursor()
+	}
+	m.cursor = row
+	return m.clampViewport()
+}
+
 // カーソルを上に移動
 func (m todoModel) moveCursorUp() todoModel {
 	if m.cursor > 0 {
@@ -98,11 +321,11 @@ func (m todoModel) moveCursorUp() todoModel {
 
 // カーソルを下に移動
 func (m todoModel) moveCursorDown() todoModel {
-	if m.cursor < len(m.items)-1 {
+	if m.cursor < len(m.rows())-1 {
 		m.cursor++
 		// ビューポートの調整
-		if m.cursor >= m.viewport+m.height {
-			m.viewport = m.cursor - m.height + 1
+		if visible := m.visibleRows(); m.cursor >= m.viewport+visible {
+			m.viewport = m.cursor - visible + 1
 		}
 	}
 	return m
@@ -110,12 +333,102 @@ func (m todoModel) moveCursorDown() todoModel {
 
 // 項目の完了状態を切り替え
 func (m todoModel) toggleItem() todoModel {
-	if m.cursor < len(m.items) {
-		m.items[m.cursor].completed = !m.items[m.cursor].completed
+	if i := m.currentIndex(); i >= 0 {
+		m.items[i] = m.items[i].withCompleted(!m.items[i].completed, m.now())
+	}
+	// 完了済みを非表示にしている場合は行が消える
+	return m.clampCursor()
+}
+
+// 優先度を切り替え（なし → 低 → 中 → 高 → なし）
+func (m todoModel) cyclePriority() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	m.items[i].priority = m.items[i].priority.next()
+	// 並び順が変わるのでカーソルをアイテムに追従させる
+	return m.cursorToItem(i)
+}
+
+// 読み込み結果の反映
+func (m todoModel) handleLoaded(msg todoLoadedMsg) todoModel {
+	m.loading = false
+	if msg.err != nil {
+		// 未保存の場合は初期データのまま始める
+		if !errors.Is(msg.err, fs.ErrNotExist) {
+			m.err = msg.err
+		}
+		return m
+	}
+	m.items = msg.snapshot.items
+	m.sortMode = msg.snapshot.sortMode
+	normalizeDepths(m.items)
+	m.cursor = 0
+	m.viewport = 0
+	m.err = nil
+	return m
+}
+
+// 変更をストアに保存
+func (m todoModel) save() todoModel {
+	// 読み込み前に保存すると初期データでファイルを上書きしてしまう
+	if m.store == nil || m.loading {
+		return m
 	}
+	m.err = m.store.Save(todoListSnapshot{items: m.items, sortMode: m.sortMode})
 	return m
 }
 
+// 絞り込み・並び順の状態表示（既定の状態では空）
+func (m todoModel) statusLine() string {
+	var parts []string
+	if m.loading {
+		parts = append(parts, "読み込み中...")
+	}
+	if m.mode == todoFiltering {
+		parts = append(parts, m.filterInput.View())
+	} else if filter := m.filterInput.Value(); filter != "" {
+		parts = append(parts, "絞り込み: "+filter)
+	}
+	if m.hideCompleted {
+		parts = append(parts, "完了を非表示")
+	}
+	if m.sortMode != sortByPriority {
+		parts = append(parts, "並び順: "+m.sortMode.String())
+	}
+	if from, to, ok := m.visualRange(); ok {
+		parts = append(parts, fmt.Sprintf("範囲選択: %d行", to-from+1))
+	} else if n := len(m.selection()); n > 0 {
+		parts = append(parts, fmt.Sprintf("選択: %d件", n))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// ビューポートに表示するアイテム数
+// 選択中のアイテムのメモや入力欄が表示する行数の分だけ減らし、カーソルの行が画面外に出ないようにする
+func (m todoModel) visibleRows() int {
+	return max(m.height-m.selectedExtraLines(), 1)
+}
+
+// 選択中のアイテムがタイトルの行の下に表示する行数（View の「選択中の行の追加入力欄・メモ」と合わせる）
+func (m todoModel) selectedExtraLines() int {
+	switch m.mode {
+	case todoEditingDue, todoEditingTags:
+		return 1
+	case todoEditingNote:
+		return m.noteInput.Height()
+	}
+	rows := m.rows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return 0
+	}
+	if note := m.items[rows[m.cursor]].note; note != "" {
+		return strings.Count(note, "\n") + 1
+	}
+	return 0
+}
+
 // View - UIの描画
 func (m todoModel) View() string {
 	// スタイル定義
@@ -135,59 +448,212 @@ func (m todoModel) View() string {
 	normalStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("7"))
 
+	markStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("13")).
+		Bold(true)
+
+	overdueStyle := lipgloss.NewStyle().
+		Foreground(styles.ErrorColor)
+
+	priorityStyle := lipgloss.NewStyle().
+		Foreground(styles.WarningColor).
+		Bold(true)
+
+	dueStyle := lipgloss.NewStyle().
+		Foreground(styles.SecondaryColor)
+
+	tagStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("15")).
+		Background(lipgloss.Color("237"))
+
+	progressStyle := lipgloss.NewStyle().
+		Foreground(styles.SuccessColor)
+
+	statusStyle := lipgloss.NewStyle().
+		Foreground(styles.SecondaryColor)
+
+	noteStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")).
+		PaddingLeft(6)
+
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("8")).
 		Italic(true).
 		MarginTop(1)
 
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("9")).
+		MarginTop(1)
+
 	borderStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("12")).
 		Padding(1, 2)
 
+	now := m.now()
+
 	// コンテンツの構築
 	var content strings.Builder
 	content.WriteString(titleStyle.Render("📝 TODOリスト"))
 	content.WriteString("\n\n")
 
 	// アイテムの表示（ビューポート内のみ）
-	end := m.viewport + m.height
-	if end > len(m.items) {
-		end = len(m.items)
+	rows := m.rows()
+	end := m.viewport + m.visibleRows()
+	if end > len(rows) {
+		end = len(rows)
 	}
 
-	for i := m.viewport; i < end; i++ {
-		item := m.items[i]
-		checkbox := "[ ]"
+	// 絞り込み・並び順の状態
+	if status := m.statusLine(); status != "" {
+		content.WriteString(statusStyle.Render(status))
+		content.WriteString("\n")
+	}
+
+	if len(rows) == 0 {
+		if len(m.items) == 0 {
+			content.WriteString(normalStyle.Render("  アイテムがありません（a: 追加）"))
+		} else {
+			content.WriteString(normalStyle.Render("  一致するアイテムがありません"))
+		}
+	}
+
+	for row := m.viewport; row < end; row++ {
+		index := rows[row]
+		item := m.items[index]
+		selected := row == m.cursor
+		marked := m.isSelected(row)
+
+		// 行頭の記号（カーソルは >、複数選択は ●）
+		prefix := "  "
+		switch {
+		case selected && marked:
+			prefix = cursorStyle.Render(">") + markStyle.Render("●")
+		case selected:
+			prefix = cursorStyle.Render("> ")
+		case marked:
+			prefix = " " + markStyle.Render("●")
+		}
+
+		// 階層のインデントと折りたたみ記号
+		indent := strings.Repeat("  ", item.depth)
+		fold := "  "
+		if m.hasChildren(index) {
+			fold = "▾ "
+			if item.collapsed {
+				fold = "▸ "
+			}
+		}
+
+		checkbox := indent + fold + "[ ]"
 		if item.completed {
-			checkbox = "[✓]"
+			checkbox = indent + fold + "[✓]"
+		}
+		if marker := item.priority.marker(); marker != "" {
+			checkbox += " " + priorityStyle.Render(marker)
 		}
 
-		line := fmt.Sprintf("%s %s", checkbox, item.title)
+		switch {
+		case selected && (m.mode == todoEditing || m.mode == todoAdding):
+			// タイトル入力中の行はtextinputを埋め込む
+			content.WriteString(prefix + checkbox + " " + m.input.View())
+		default:
+			// スタイルの適用
+			title := item.title
+			switch {
+			case selected:
+				content.WriteString(prefix + checkbox + " " + cursorStyle.Render(title))
+			case marked:
+				content.WriteString(prefix + checkbox + " " + markStyle.Strikethrough(item.completed).Render(title))
+			case item.completed:
+				content.WriteString(completedStyle.Render("  " + checkbox + " " + title))
+			case item.overdue(now):
+				content.WriteString("  " + checkbox + " " + overdueStyle.Render(title))
+			default:
+				content.WriteString(normalStyle.Render("  " + checkbox + " " + title))
+			}
+
+			// タグのチップ
+			for _, tag := range item.tags {
+				content.WriteString(" " + tagStyle.Render("#"+tag))
+			}
 
-		// スタイルの適用
-		if i == m.cursor {
-			content.WriteString(cursorStyle.Render("> " + line))
-		} else if item.completed {
-			content.WriteString(completedStyle.Render("  " + line))
-		} else {
-			content.WriteString(normalStyle.Render("  " + line))
+			// 期日
+			if !item.due.IsZero() {
+				label := "📅 " + formatDueDate(item.due, now)
+				if item.overdue(now) {
+					content.WriteString(" " + overdueStyle.Render(label))
+				} else {
+					content.WriteString(" " + dueStyle.Render(label))
+				}
+			}
+
+			if item.note != "" {
+				content.WriteString(" 📝")
+			}
+
+			// 子孫の進捗
+			if m.hasChildren(index) {
+				done, total := m.progress(index)
+				content.WriteString(" " + progressStyle.Render(fmt.Sprintf("(%d/%d)", done, total)))
+			}
+		}
+
+		// 選択中の行の追加入力欄・メモ
+		if selected {
+			switch m.mode {
+			case todoEditingDue:
+				content.WriteString("\n" + noteStyle.Render("期日: ") + m.input.View())
+			case todoEditingTags:
+				content.WriteString("\n" + noteStyle.Render("タグ: ") + m.input.View())
+			case todoEditingNote:
+				content.WriteString("\n" + lipgloss.NewStyle().PaddingLeft(6).Render(m.noteInput.View()))
+			default:
+				if item.note != "" {
+					content.WriteString("\n" + noteStyle.Render(item.note))
+				}
+			}
 		}
 
-		if i < end-1 {
+		if row < end-1 {
 			content.WriteString("\n")
 		}
 	}
 
 	// スクロールインジケーター
-	if len(m.items) > m.height {
-		scrollInfo := fmt.Sprintf("\n\n[%d/%d]", m.cursor+1, len(m.items))
+	if len(rows) > m.height {
+		scrollInfo := fmt.Sprintf("\n\n[%d/%d]", m.cursor+1, len(rows))
 		content.WriteString(normalStyle.Render(scrollInfo))
 	}
 
+	// 保存・読み込みエラー
+	if m.err != nil {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render("❌ " + m.err.Error()))
+	}
+
 	// ヘルプテキスト
-	help := helpStyle.Render("\n↑/k: 上へ  ↓/j: 下へ  Enter/Space: 選択  q: 終了")
-	content.WriteString(help)
+	var help string
+	switch m.mode {
+	case todoNormal:
+		help = "\n↑/k: 上へ  ↓/j: 下へ  Enter/Space: 選択  q: 終了\n" +
+			"a/o: 追加  e: 編集  dd: 削除  u: 削除を元に戻す\n" +
+			"p: 優先度  D: 期日  t: タグ  n: メモ\n" +
+			"/: 絞り込み  c: 完了を隠す  s: 並び順\n" +
+			"A: サブタスク追加  >/<: 階層変更  h/l: 折りたたみ/展開  x: 子孫ごと完了\n" +
+			"v: 選択  V: 範囲選択  M: 選択をここへ移動  Esc: 選択解除\n" +
+			"K/J, Alt+↑/↓: 並べ替え（手動の並び順になる）"
+	case todoVisual:
+		help = "\n↑/k ↓/j: 範囲を変更  V: 選択を確定  Esc: キャンセル\n" +
+			"Enter/Space: 完了切り替え  x: 子孫ごと  d: 削除  t: タグ"
+	case todoFiltering:
+		help = "\nEnter: 決定  Esc: 解除"
+	case todoEditingNote:
+		help = "\nCtrl+S: 確定  Esc: キャンセル"
+	default:
+		help = "\nEnter: 確定  Esc: キャンセル"
+	}
+	content.WriteString(helpStyle.Render(help))
 
 	return borderStyle.Render(content.String())
 }
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// テキスト形式のファイルの内容
+type todoDocument struct {
+	items   []todoItem
+	trailer []string // 最後のアイテムより後ろにあった行
+}
+
+// テキスト形式とアイテムを相互に変換するコーデック
+// 解釈できない部分はタイトルや leading に残し、読み書きを往復しても失われないようにする
+type todoCodec interface {
+	decode(data []byte) todoDocument
+	encode(doc todoDocument) []byte
+}
+
+// 行単位に分割（末尾の改行とCRは取り除く）
+func splitLines(data []byte) []string {
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// 日付のみのトークンを解釈
+func parseDateToken(s string) (time.Time, bool) {
+	t, err := time.ParseInLocation(dueDateLayout, s, time.Local)
+	return t, err == nil
+}
+
+// タグとして扱うトークンか（#12 のような番号はタグにしない）
+func tagToken(s, prefix string) (string, bool) {
+	tag, ok := strings.CutPrefix(s, prefix)
+	if !ok || tag == "" || unicode.IsDigit([]rune(tag)[0]) {
+		return "", false
+	}
+	return tag, true
+}
+
+// todo.txt 形式（http://todotxt.org/）
+//
+//	x 2026-10-14 2026-10-01 レポートを書く +work due:2026-10-20 pri:A
+//	(B) 2026-10-01 牛乳を買う @store +home
+//
+// 優先度は (A) が高、(B) が中、(C) 以降が低（(D) 以降の文字は優先度を変えない限りそのまま書き戻す）。
+// +project をタグとして扱い、
+// @context や未知の key:value はタイトルの一部として残す。
+// 階層は depth:N、メモは note:（空白や改行をURLエンコードした値）の拡張タグで表し、空行はそのまま残す。
+type todoTxtCodec struct{}
+
+// todo.txt の優先度
+var todoTxtPriorities = map[todoPriority]string{
+	priorityHigh:   "A",
+	priorityMedium: "B",
+	priorityLow:    "C",
+}
+
+// 書き出す優先度の文字（読み込んだ文字が今の優先度を表していればその文字）
+func todoTxtPriorityLetter(item todoItem) (string, bool) {
+	if p, ok := parseTodoTxtPriority(item.rawPriority); ok && p == item.priority {
+		return item.rawPriority, true
+	}
+	p, ok := todoTxtPriorities[item.priority]
+	return p, ok
+}
+
+// 優先度の文字を解釈
+func parseTodoTxtPriority(s string) (todoPriority, bool) {
+	if len(s) != 1 || s[0] < 'A' || s[0] > 'Z' {
+		return priorityNone, false
+	}
+	switch s {
+	case "A":
+		return priorityHigh, true
+	case "B":
+		return priorityMedium, true
+	}
+	return priorityLow, true
+}
+
+// 1行を解釈
+func parseTodoTxtLine(line string) todoItem {
+	var item todoItem
+	fields := strings.Fields(line)
+
+	if len(fields) > 0 && fields[0] == "x" {
+		item.completed = true
+		fields = fields[1:]
+		if len(fields) > 0 {
+			if done, ok := parseDateToken(fields[0]); ok {
+				item.done = done
+				fields = fields[1:]
+			}
+		}
+	} else if len(fields) > 0 && len(fields[0]) == 3 && fields[0][0] == '(' && fields[0][2] == ')' {
+		if p, ok := parseTodoTxtPriority(fields[0][1:2]); ok {
+			item.priority, item.rawPriority = p, fields[0][1:2]
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 {
+		if created, ok := parseDateToken(fields[0]); ok {
+			item.created = created
+			fields = fields[1:]
+		}
+	}
+
+	var words []string
+	for _, f := range fields {
+		if tag, ok := tagToken(f, "+"); ok {
+			item.tags = append(item.tags, tag)
+			continue
+		}
+		if v, ok := strings.CutPrefix(f, "due:"); ok {
+			if due, ok := parseDateToken(v); ok {
+				item.due = due
+				continue
+			}
+		}
+		if v, ok := strings.CutPrefix(f, "depth:"); ok {
+			if depth, err := strconv.Atoi(v); err == nil && depth > 0 {
+				item.depth = depth
+				continue
+			}
+		}
+		if v, ok := strings.CutPrefix(f, "note:"); ok {
+			if note, err := url.PathUnescape(v); err == nil && note != "" {
+				item.note = note
+				continue
+			}
+		}
+		if v, ok := strings.CutPrefix(f, "pri:"); ok {
+			// 完了済みのアイテムの優先度
+			if p, ok := parseTodoTxtPriority(v); ok {
+				item.priority, item.rawPriority = p, v
+				continue
+			}
+		}
+		words = append(words, f)
+	}
+	item.title = strings.Join(words, " ")
+	return item
+}
+
+// 1行に変換
+func formatTodoTxtLine(item todoItem) string {
+	var fields []string
+	if item.completed {
+		fields = append(fields, "x")
+		if !item.done.IsZero() {
+			fields = append(fields, item.done.Format(dueDateLayout))
+		}
+	} else if p, ok := todoTxtPriorityLetter(item); ok {
+		fields = append(fields, "("+p+")")
+	}
+	// 完了日がない完了済みアイテムでは作成日が完了日と区別できないので書かない
+	if !item.created.IsZero() && (!item.completed || !item.done.IsZero()) {
+		fields = append(fields, item.created.Format(dueDateLayout))
+	}
+	if item.title != "" {
+		fields = append(fields, item.title)
+	}
+	for _, tag := range item.tags {
+		fields = append(fields, "+"+tag)
+	}
+	if !item.due.IsZero() {
+		fields = append(fields, "due:"+item.due.Format(dueDateLayout))
+	}
+	if p, ok := todoTxtPriorityLetter(item); ok && item.completed {
+		fields = append(fields, "pri:"+p)
+	}
+	if item.depth > 0 {
+		fields = append(fields, "depth:"+strconv.Itoa(item.depth))
+	}
+	if item.note != "" {
+		fields = append(fields, "note:"+url.PathEscape(item.note))
+	}
+	return strings.Join(fields, " ")
+}
+
+func (todoTxtCodec) decode(data []byte) todoDocument {
+	var doc todoDocument
+	var blanks []string // まだアイテムに付けていない空行
+	for _, line := range splitLines(data) {
+		if strings.TrimSpace(line) == "" {
+			blanks = append(blanks, line)
+			continue
+		}
+		item := parseTodoTxtLine(line)
+		// 親のない階層にならないよう直前のアイテムの1つ下までにする
+		parent := -1
+		if len(doc.items) > 0 {
+			parent = doc.items[len(doc.items)-1].depth
+		}
+		item.depth = min(item.depth, parent+1)
+		item.leading, blanks = blanks, nil
+		doc.items = append(doc.items, item)
+	}
+	doc.trailer = blanks
+	return doc
+}
+
+func (todoTxtCodec) encode(doc todoDocument) []byte {
+	var b strings.Builder
+	for _, item := range doc.items {
+		for _, line := range item.leading {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString(formatTodoTxtLine(item))
+		b.WriteByte('\n')
+	}
+	for _, line := range doc.trailer {
+		b.WriteString(line + "\n")
+	}
+	return []byte(b.String())
+}
+
+// GitHub形式のMarkdownチェックリスト
+//
+//	## 仕事
+//	- [ ] レポートを書く #work ⏫ 📅 2026-10-20
+//	  - [x] 資料を集める ✅ 2026-10-14
+//	    メモはチェックボックスより深くインデントした行
+//
+// 期日・優先度・作成日・完了日は Obsidian Tasks の絵文字表記に合わせる。
+// 見出しなどチェックリスト以外の行は直後のアイテムの leading として保持する。
+type markdownTodoCodec struct{}
+
+// チェックリストの行
+var markdownTodoRegex = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+\[([ xX])\](?:[ \t]+(.*))?$`)
+
+// Markdownの優先度の絵文字
+var markdownPriorities = map[todoPriority]string{
+	priorityHigh:   "⏫",
+	priorityMedium: "🔼",
+	priorityLow:    "🔽",
+}
+
+// 日付の絵文字
+const (
+	markdownDueEmoji     = "📅"
+	markdownCreatedEmoji = "➕"
+	markdownDoneEmoji    = "✅"
+)
+
+// インデントの幅（タブは4桁として数える）
+func indentWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// チェックボックスの後ろのテキストを解釈
+func parseMarkdownTodoText(text string) todoItem {
+	var item todoItem
+	var words []string
+	fields := strings.Fields(text)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if tag, ok := tagToken(f, "#"); ok {
+			item.tags = append(item.tags, tag)
+			continue
+		}
+		if p, ok := markdownPriority(f); ok {
+			item.priority = p
+			continue
+		}
+		if i+1 < len(fields) {
+			if date, ok := parseDateToken(fields[i+1]); ok {
+				switch f {
+				case markdownDueEmoji:
+					item.due = date
+					i++
+					continue
+				case markdownCreatedEmoji:
+					item.created = date
+					i++
+					continue
+				case markdownDoneEmoji:
+					item.done = date
+					i++
+					continue
+				}
+			}
+		}
+		words = append(words, f)
+	}
+	item.title = strings.Join(words, " ")
+	return item
+}
+
+// 優先度の絵文字を解釈（Obsidian Tasks の最高・最低はそれぞれ高・低として扱う）
+func markdownPriority(s string) (todoPriority, bool) {
+	switch s {
+	case "🔺":
+		return priorityHigh, true
+	case "⏬":
+		return priorityLow, true
+	}
+	for p, emoji := range markdownPriorities {
+		if emoji == s {
+			return p, true
+		}
+	}
+	return priorityNone, false
+}
+
+// チェックボックスの後ろのテキストに変換
+func formatMarkdownTodoText(item todoItem) string {
+	var fields []string
+	if item.title != "" {
+		fields = append(fields, item.title)
+	}
+	for _, tag := range item.tags {
+		fields = append(fields, "#"+tag)
+	}
+	if emoji, ok := markdownPriorities[item.priority]; ok {
+		fields = append(fields, emoji)
+	}
+	if !item.created.IsZero() {
+		fields = append(fields, markdownCreatedEmoji, item.created.Format(dueDateLayout))
+	}
+	if !item.due.IsZero() {
+		fields = append(fields, markdownDueEmoji, item.due.Format(dueDateLayout))
+	}
+	if !item.done.IsZero() {
+		fields = append(fields, markdownDoneEmoji, item.done.Format(dueDateLayout))
+	}
+	return strings.Join(fields, " ")
+}
+
+func (markdownTodoCodec) decode(data []byte) todoDocument {
+	var doc todoDocument
+	var pending []string // まだアイテムに付けていない行
+	var indents []int    // 祖先のチェックボックスのインデント幅
+	var blanks []string  // メモの後ろの空行
+	noteIndent := -1     // 直前のアイテムのメモとして扱うインデント幅（-1はメモを受け付けない）
+
+	for _, line := range splitLines(data) {
+		if m := markdownTodoRegex.FindStringSubmatch(line); m != nil {
+			indent := indentWidth(m[1])
+			for len(indents) > 0 && indents[len(indents)-1] >= indent {
+				indents = indents[:len(indents)-1]
+			}
+			item := parseMarkdownTodoText(m[3])
+			item.completed = m[2] != " "
+			item.depth = len(indents)
+			item.leading = append(pending, blanks...)
+			pending, blanks = nil, nil
+			indents = append(indents, indent)
+			noteIndent = indent + 1
+			doc.items = append(doc.items, item)
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if noteIndent >= 0 && trimmed == "" {
+			// メモの途中の空行かもしれないので保留する
+			blanks = append(blanks, line)
+			continue
+		}
+		if noteIndent >= 0 && indentWidth(line[:len(line)-len(trimmed)]) >= noteIndent {
+			last := &doc.items[len(doc.items)-1]
+			if last.note != "" {
+				last.note += strings.Repeat("\n", len(blanks)+1)
+			}
+			last.note += trimmed
+			blanks = nil
+			continue
+		}
+
+		// チェックリスト以外の行はそのまま残す
+		pending = append(pending, blanks...)
+		pending = append(pending, line)
+		blanks = nil
+		noteIndent = -1
+	}
+	pending = append(pending, blanks...)
+	doc.trailer = pending
+	return doc
+}
+
+func (markdownTodoCodec) encode(doc todoDocument) []byte {
+	var b strings.Builder
+	for _, item := range doc.items {
+		for _, line := range item.leading {
+			b.WriteString(line + "\n")
+		}
+		indent := strings.Repeat("  ", item.depth)
+		check := " "
+		if item.completed {
+			check = "x"
+		}
+		b.WriteString(strings.TrimRight(indent+"- ["+check+"] "+formatMarkdownTodoText(item), " ") + "\n")
+		if item.note != "" {
+			for _, line := range strings.Split(item.note, "\n") {
+				if line != "" {
+					line = indent + "  " + line
+				}
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+	for _, line := range doc.trailer {
+		b.WriteString(line + "\n")
+	}
+	return []byte(b.String())
+}
+
+// テキスト形式のファイルに保存するストア
+type fileTodoStore struct {
+	path    string
+	codec   todoCodec
+	trailer []string // 読み込んだファイルの末尾の行（書き出し時に戻す）
+}
+
+// コンストラクタ
+func newFileTodoStore(path string, codec todoCodec) *fileTodoStore {
+	return &fileTodoStore{path: path, codec: codec}
+}
+
+// 拡張子に応じたストアを選ぶ（.json / .txt / .md）
+func newTodoStoreForPath(path string) (todoStore, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return newJSONTodoStore(path), nil
+	case ".txt":
+		return newFileTodoStore(path, todoTxtCodec{}), nil
+	case ".md", ".markdown":
+		return newFileTodoStore(path, markdownTodoCodec{}), nil
+	}
+	return nil, fmt.Errorf("未対応のファイル形式です: %s（.json / .txt / .md に対応）", path)
+}
+
+// Load - ファイルから読み込み（テキスト形式ではファイル上の順がそのまま手動の並び順になる）
+func (s *fileTodoStore) Load() (todoListSnapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// 指定されたファイルを新しく作る場合は初期データを入れずに空で始める
+		return todoListSnapshot{sortMode: sortManual}, nil
+	}
+	if err != nil {
+		return todoListSnapshot{}, err
+	}
+	doc := s.codec.decode(data)
+	s.trailer = doc.trailer
+	return todoListSnapshot{items: doc.items, sortMode: sortManual}, nil
+}
+
+// Save - 一時ファイルへ書き込んでからリネームする（並び順は保存しない）
+func (s *fileTodoStore) Save(snapshot todoListSnapshot) error {
+	data := s.codec.encode(todoDocument{items: snapshot.items, trailer: s.trailer})
+	return storage.WriteFileAtomic(s.path, data, 0o644)
+}
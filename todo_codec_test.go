@@ -0,0 +1,244 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTodoTxtCodec(t *testing.T) {
+	t.Run("行の解釈", func(t *testing.T) {
+		tests := []struct {
+			line     string
+			expected todoItem
+		}{
+			{
+				"牛乳を買う",
+				todoItem{title: "牛乳を買う"},
+			},
+			{
+				"(A) 2026-10-01 レポートを書く +work @office due:2026-10-20",
+				todoItem{title: "レポートを書く @office", priority: priorityHigh, rawPriority: "A", created: todoTestDate(10, 1), tags: []string{"work"}, due: todoTestDate(10, 20)},
+			},
+			{
+				"x 2026-10-14 2026-10-01 会議の準備 pri:B",
+				todoItem{title: "会議の準備", completed: true, done: todoTestDate(10, 14), created: todoTestDate(10, 1), priority: priorityMedium, rawPriority: "B"},
+			},
+			{
+				"(D) 未知の値を残す t:2026-10-15 due:someday issue +1",
+				todoItem{title: "未知の値を残す t:2026-10-15 due:someday issue +1", priority: priorityLow, rawPriority: "D"},
+			},
+			{
+				"xylophone を練習する",
+				todoItem{title: "xylophone を練習する"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.line, func(t *testing.T) {
+				if item := parseTodoTxtLine(tt.line); !reflect.DeepEqual(item, tt.expected) {
+					t.Errorf("parseTodoTxtLine(%q) = %+v, 期待値: %+v", tt.line, item, tt.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("読み書きの往復", func(t *testing.T) {
+		input := "(A) 2026-10-01 レポートを書く @office +work due:2026-10-20\n" +
+			"\n" +
+			"x 2026-10-14 2026-10-01 会議の準備 rec:1w pri:B\n" +
+			"牛乳を買う +home\n"
+		codec := todoTxtCodec{}
+
+		doc := codec.decode([]byte(input))
+		if len(doc.items) != 3 {
+			t.Fatalf("空行を除いて3件になるべき、実際: %d件", len(doc.items))
+		}
+		if output := string(codec.encode(doc)); output != input {
+			t.Errorf("往復で内容が変わった:\n期待:\n%s\n実際:\n%s", input, output)
+		}
+	})
+
+	t.Run("階層とメモ", func(t *testing.T) {
+		codec := todoTxtCodec{}
+		items := []todoItem{
+			{title: "旅行の準備", note: "予算は 5万円\n100% 決定ではない"},
+			{title: "宿を予約する", depth: 1},
+			{title: "キャンセル規定を確認", depth: 2, note: "メモ"},
+			{title: "荷造り"},
+		}
+		data := codec.encode(todoDocument{items: items, trailer: []string{""}})
+		if !strings.Contains(string(data), "宿を予約する depth:1\n") {
+			t.Errorf("階層を拡張タグで書き出すべき:\n%s", data)
+		}
+
+		doc := codec.decode(data)
+		if !reflect.DeepEqual(doc.items, items) || len(doc.trailer) != 1 {
+			t.Errorf("階層・メモ・空行が往復で失われないべき、実際: %+v", doc)
+		}
+		if output := codec.encode(doc); string(output) != string(data) {
+			t.Errorf("往復で内容が変わった:\n期待:\n%s\n実際:\n%s", data, output)
+		}
+
+		if doc := codec.decode([]byte("親がない depth:3\n")); doc.items[0].depth != 0 {
+			t.Errorf("親のない階層はルートにするべき、実際: %d", doc.items[0].depth)
+		}
+	})
+
+	t.Run("(D) 以降の優先度の文字", func(t *testing.T) {
+		input := "(D) 母に電話する\n(Z) あとで\nx 2026-10-14 済んだこと pri:E\n"
+		codec := todoTxtCodec{}
+		doc := codec.decode([]byte(input))
+		if output := string(codec.encode(doc)); output != input {
+			t.Errorf("優先度の文字を変えずに書き戻すべき:\n期待:\n%s\n実際:\n%s", input, output)
+		}
+
+		doc.items[0].priority = doc.items[0].priority.next()
+		if line := formatTodoTxtLine(doc.items[0]); strings.HasPrefix(line, "(D)") {
+			t.Errorf("優先度を変えたら新しい優先度で書き出すべき、実際: %q", line)
+		}
+	})
+
+	t.Run("完了日のない完了済みアイテム", func(t *testing.T) {
+		item := todoItem{title: "古いタスク", completed: true, created: todoTestDate(10, 1)}
+		if line := formatTodoTxtLine(item); line != "x 古いタスク" {
+			t.Errorf("作成日を完了日と誤解されないように省くべき、実際: %q", line)
+		}
+	})
+}
+
+func TestMarkdownTodoCodec(t *testing.T) {
+	t.Run("チェックリストの解釈", func(t *testing.T) {
+		input := "# タスク\n" +
+			"\n" +
+			"- [ ] レポートを書く #work ⏫ 📅 2026-10-20\n" +
+			"  - [X] 資料を集める ✅ 2026-10-14\n" +
+			"    参考資料のURL\n" +
+			"\n" +
+			"    二段落目\n" +
+			"* [ ] Issue #12 を直す 🔽\n" +
+			"- 普通の箇条書き\n"
+
+		doc := markdownTodoCodec{}.decode([]byte(input))
+
+		expected := []todoItem{
+			{title: "レポートを書く", tags: []string{"work"}, priority: priorityHigh, due: todoTestDate(10, 20), leading: []string{"# タスク", ""}},
+			{title: "資料を集める", completed: true, done: todoTestDate(10, 14), depth: 1, note: "参考資料のURL\n\n二段落目"},
+			{title: "Issue #12 を直す", priority: priorityLow},
+		}
+		if !reflect.DeepEqual(doc.items, expected) {
+			t.Errorf("解釈結果が正しくない:\n期待: %+v\n実際: %+v", expected, doc.items)
+		}
+		if !reflect.DeepEqual(doc.trailer, []string{"- 普通の箇条書き"}) {
+			t.Errorf("末尾の行は保持されるべき、実際: %q", doc.trailer)
+		}
+	})
+
+	t.Run("読み書きの往復", func(t *testing.T) {
+		input := "# プロジェクト\n" +
+			"\n" +
+			"説明文はそのまま残す。\n" +
+			"\n" +
+			"## 今週\n" +
+			"- [ ] レポートを書く #work ⏫ ➕ 2026-10-01 📅 2026-10-20\n" +
+			"  - [x] 資料を集める ✅ 2026-10-14\n" +
+			"    メモ1行目\n" +
+			"\n" +
+			"    メモ2行目\n" +
+			"  - [ ] 下書き 🛫 2026-10-15\n" +
+			"- [-] キャンセル済み（未知の状態）\n" +
+			"- [ ] 牛乳を買う\n" +
+			"\n" +
+			"---\n" +
+			"末尾のメモ\n"
+		codec := markdownTodoCodec{}
+
+		output := string(codec.encode(codec.decode([]byte(input))))
+
+		if output != input {
+			t.Errorf("往復で内容が変わった:\n期待:\n%s\n実際:\n%s", input, output)
+		}
+	})
+
+	t.Run("タブとCRLF", func(t *testing.T) {
+		input := "- [ ] 親\r\n\t- [ ] 子\r\n"
+
+		doc := markdownTodoCodec{}.decode([]byte(input))
+
+		if len(doc.items) != 2 || doc.items[1].depth != 1 {
+			t.Errorf("タブのインデントで階層になるべき、実際: %+v", doc.items)
+		}
+	})
+}
+
+func TestFileTodoStore(t *testing.T) {
+	t.Run("拡張子でストアを選ぶ", func(t *testing.T) {
+		tests := []struct {
+			path     string
+			expected todoStore
+		}{
+			{"todo.json", newJSONTodoStore("todo.json")},
+			{"todo.txt", newFileTodoStore("todo.txt", todoTxtCodec{})},
+			{"TASKS.MD", newFileTodoStore("TASKS.MD", markdownTodoCodec{})},
+			{"tasks.markdown", newFileTodoStore("tasks.markdown", markdownTodoCodec{})},
+		}
+		for _, tt := range tests {
+			t.Run(tt.path, func(t *testing.T) {
+				store, err := newTodoStoreForPath(tt.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(store, tt.expected) {
+					t.Errorf("ストアが正しくない: 期待 %#v, 実際 %#v", tt.expected, store)
+				}
+			})
+		}
+
+		if _, err := newTodoStoreForPath("todo.csv"); err == nil {
+			t.Error("未対応の拡張子はエラーになるべき")
+		}
+	})
+
+	t.Run("存在しないファイルは空で始まる", func(t *testing.T) {
+		store := newFileTodoStore(filepath.Join(t.TempDir(), "new.md"), markdownTodoCodec{})
+
+		snapshot, err := store.Load()
+
+		if err != nil || len(snapshot.items) != 0 || snapshot.sortMode != sortManual {
+			t.Errorf("手動の並び順の空の一覧になるべき: %+v err=%v", snapshot, err)
+		}
+	})
+
+	t.Run("モデルで編集して保存しても見出しと末尾が残る", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tasks.md")
+		input := "# 買い物\n- [ ] 牛乳\n- [ ] パン\n\n# 仕事\n- [ ] レポート\n- [ ] 会議\n\n最終更新: 10/14\n"
+		if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		store := newFileTodoStore(path, markdownTodoCodec{})
+		m := NewTodoModelWithStore(store)
+		m.now = func() time.Time { return todoTestNow }
+		m = m.handleLoaded(m.Init()().(todoLoadedMsg))
+
+		// 牛乳を完了にして、見出し付きの「レポート」を削除する
+		m = m.cursorToItem(0)
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeySpace})
+		m = m.cursorToItem(2)
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "# 買い物\n- [x] 牛乳 ✅ 2026-10-14\n- [ ] パン\n\n# 仕事\n- [ ] 会議\n\n最終更新: 10/14\n"
+		if string(data) != expected {
+			t.Errorf("保存内容が正しくない:\n期待:\n%s\n実際:\n%s", expected, data)
+		}
+	})
+}
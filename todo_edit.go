@@ -0,0 +1,306 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+)
+
+// TODOリストの入力モード
+type todoMode int
+
+const (
+	todoNormal      todoMode = iota // カーソル移動・切り替え
+	todoAdding                      // 新規アイテムのタイトル入力中
+	todoEditing                     // 既存アイテムのタイトル編集中
+	todoEditingDue                  // 期日の入力中
+	todoEditingTags                 // タグの入力中
+	todoEditingNote                 // メモの入力中
+	todoFiltering                   // 絞り込みの入力中
+	todoVisual                      // 範囲選択中
+)
+
+// 削除したアイテムと子孫（元に戻す用）
+type deletedTodo struct {
+	items   []todoItem
+	index   int
+	leading [][]string // 次のアイテムへ引き継いだ items それぞれの leading（引き継いでいなければ nil）
+}
+
+// タイトル入力用のtextinputを生成
+func newTodoInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "新しいTODO"
+	ti.Prompt = ""
+	ti.CharLimit = constants.TodoTitleMaxLength
+	ti.Width = 40
+	return ti
+}
+
+// メモ入力用のtextareaを生成
+func newTodoNoteInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "メモを入力"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = constants.TodoNoteMaxLength
+	ta.SetWidth(40)
+	ta.SetHeight(4)
+	return ta
+}
+
+// カーソルの下に兄弟として新規アイテムを追加して入力モードへ
+func (m todoModel) startAdding() (todoModel, tea.Cmd) {
+	index, depth := 0, 0
+	if i := m.currentIndex(); i >= 0 {
+		// 子孫の後ろに追加する
+		index, depth = m.subtreeEnd(i), m.items[i].depth
+	}
+	return m.insertNewItem(index, depth)
+}
+
+// カーソル位置のアイテムの最後の子として新規アイテムを追加して入力モードへ
+func (m todoModel) startAddingChild() (todoModel, tea.Cmd) {
+	i := m.currentIndex()
+	if i < 0 {
+		return m.startAdding()
+	}
+	m.items[i].collapsed = false
+	return m.insertNewItem(m.subtreeEnd(i), m.items[i].depth+1)
+}
+
+// 新規アイテムを挿入して入力モードへ
+func (m todoModel) insertNewItem(index, depth int) (todoModel, tea.Cmd) {
+	m.items = slices.Insert(slices.Clone(m.items), index, todoItem{created: m.now(), depth: depth})
+	m.prevCursor = m.cursor
+	m.mode = todoAdding
+	m.addingIdx = index
+	m = m.cursorToItem(index)
+
+	m.input.Placeholder = "新しいTODO"
+	m.input.SetValue("")
+	return m, m.input.Focus()
+}
+
+// カーソル位置のアイテムを指定したモードで編集
+func (m todoModel) startEditing(mode todoMode) (todoModel, tea.Cmd) {
+	i := m.currentIndex()
+	if i < 0 {
+		return m, nil
+	}
+	item := m.items[i]
+	m.mode = mode
+	// 入力欄の行が増えてもカーソルの行が見えるようにする
+	m = m.clampViewport()
+
+	switch mode {
+	case todoEditingNote:
+		m.noteInput.SetValue(item.note)
+		return m, m.noteInput.Focus()
+	case todoEditingDue:
+		m.input.Placeholder = "例: tomorrow, next fri, 10/20（空で解除）"
+		m.input.SetValue("")
+		if !item.due.IsZero() {
+			m.input.SetValue(item.due.Format(dueDateLayout))
+		}
+	case todoEditingTags:
+		m.input.Placeholder = "例: work home（空白区切り）"
+		m.input.SetValue(formatTags(item.tags))
+		if m.hasSelection() {
+			m.input.Placeholder = "例: work -home（選択中に追加/-で削除）"
+			m.input.SetValue("")
+		}
+	default:
+		m.input.Placeholder = "タイトル"
+		m.input.SetValue(item.title)
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+// 入力モード中のメッセージ処理
+func (m todoModel) updateInput(msg tea.Msg) (todoModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			// メモはEnterで改行する
+			if m.mode != todoEditingNote {
+				return m.commitInput(), nil
+			}
+		case tea.KeyCtrlS:
+			return m.commitInput(), nil
+		case tea.KeyEsc:
+			return m.cancelInput(), nil
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.mode == todoEditingNote {
+		m.noteInput, cmd = m.noteInput.Update(msg)
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+// 入力内容を確定
+func (m todoModel) commitInput() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m.cancelInput()
+	}
+	value := strings.TrimSpace(m.input.Value())
+
+	switch m.mode {
+	case todoAdding, todoEditing:
+		if value == "" {
+			// 空のタイトルはキャンセル扱い
+			return m.cancelInput()
+		}
+		m.items[i].title = value
+	case todoEditingDue:
+		due, err := parseDueDate(value, m.now())
+		if err != nil {
+			// 入力モードのまま修正してもらう
+			m.err = err
+			return m
+		}
+		m.items[i].due = due
+	case todoEditingTags:
+		if m.hasSelection() {
+			m = m.bulkRetag(value)
+		} else {
+			m.items[i].tags = parseTags(value)
+		}
+	case todoEditingNote:
+		m.items[i].note = strings.TrimSpace(m.noteInput.Value())
+	}
+
+	m.err = nil
+	m = m.endInput()
+	// 期日の変更で並び順が変わるのでカーソルをアイテムに追従させる
+	return m.cursorToItem(i).save()
+}
+
+// 入力をキャンセル
+func (m todoModel) cancelInput() todoModel {
+	m.err = nil
+	if m.mode != todoAdding {
+		return m.endInput()
+	}
+
+	// 追加途中の空アイテムを取り除く
+	m = m.endInput()
+	m.items = slices.Delete(slices.Clone(m.items), m.addingIdx, m.addingIdx+1)
+	m.cursor = m.prevCursor
+	return m.clampCursor()
+}
+
+// 入力モードを終了
+func (m todoModel) endInput() todoModel {
+	m.mode = todoNormal
+	m.input.Blur()
+	m.noteInput.Blur()
+	return m.clampViewport()
+}
+
+// カーソル位置のアイテムを子孫ごと削除
+func (m todoModel) deleteItem() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	m, block := m.removeSubtree(i)
+	m.deleted = append(m.deleted, []deletedTodo{block})
+	return m.clampCursor()
+}
+
+// アイテムを子孫ごと取り除く
+func (m todoModel) removeSubtree(i int) (todoModel, deletedTodo) {
+	end := m.subtreeEnd(i)
+	block := slices.Clone(m.items[i:end])
+	m.items = slices.Delete(slices.Clone(m.items), i, end)
+
+	// Markdownの見出しなどはアイテムと一緒に消さず、次のアイテムへ引き継ぐ
+	// （次のアイテムがない場合は削除したアイテムに残り、元に戻すと復元される）
+	deleted := deletedTodo{items: block, index: i}
+	if i < len(m.items) {
+		var leading []string
+		deleted.leading = make([][]string, len(block))
+		for j := range block {
+			leading = append(leading, block[j].leading...)
+			deleted.leading[j] = block[j].leading
+			block[j].leading = nil
+		}
+		m.items[i].leading = append(leading, slices.Clone(m.items[i].leading)...)
+	}
+	return m, deleted
+}
+
+// 削除時に次のアイテムへ引き継いだ leading を、復元したアイテムへ戻す
+// （その後の操作で次のアイテムの leading が変わっている場合は引き継いだままにする）
+func (m todoModel) restoreLeading(block deletedTodo) todoModel {
+	next := block.index + len(block.items)
+	if block.leading == nil || next >= len(m.items) {
+		return m
+	}
+	moved := slices.Concat(block.leading...)
+	leading := m.items[next].leading
+	if len(leading) < len(moved) || !slices.Equal(leading[:len(moved)], moved) {
+		return m
+	}
+	m.items[next].leading = slices.Clip(leading[len(moved):])
+	if len(m.items[next].leading) == 0 {
+		m.items[next].leading = nil
+	}
+	for j := range block.items {
+		m.items[block.index+j].leading = block.leading[j]
+	}
+	return m
+}
+
+// 直前の削除を元に戻す
+func (m todoModel) undoDelete() todoModel {
+	if len(m.deleted) == 0 {
+		return m
+	}
+	group := m.deleted[len(m.deleted)-1]
+	m.deleted = m.deleted[:len(m.deleted)-1]
+
+	// 削除した順の逆に戻すと、それぞれの位置が削除した時点と一致する
+	index := 0
+	for _, block := range slices.Backward(group) {
+		index = min(block.index, len(m.items))
+		m.items = slices.Insert(slices.Clone(m.items), index, block.items...)
+		if index == block.index {
+			m = m.restoreLeading(block)
+		}
+	}
+	// 削除後の変更で親が変わっている場合に備えて階層を整える
+	normalizeDepths(m.items)
+	return m.cursorToItem(index)
+}
+
+// カーソルがビューポート内に収まるように調整
+func (m todoModel) clampViewport() todoModel {
+	visible := m.visibleRows()
+	if m.cursor < m.viewport {
+		m.viewport = m.cursor
+	}
+	if m.cursor >= m.viewport+visible {
+		m.viewport = m.cursor - visible + 1
+	}
+	// 削除で末尾に空行ができないように詰める
+	if n := len(m.rows()); m.viewport > n-visible {
+		m.viewport = n - visible
+	}
+	if m.viewport < 0 {
+		m.viewport = 0
+	}
+	return m
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 文字列をキー入力としてモデルに送る
+func typeTodoText(m todoModel, text string) todoModel {
+	for _, r := range text {
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(todoModel)
+	}
+	return m
+}
+
+// キーを送ってtodoModelを返す
+func sendTodoKey(m todoModel, msg tea.KeyMsg) todoModel {
+	newModel, _ := m.Update(msg)
+	return newModel.(todoModel)
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTodoModelEdit(t *testing.T) {
+	t.Run("aキーで追加モードに入る", func(t *testing.T) {
+		m := NewTodoModel()
+		initialCount := len(m.items)
+
+		newModel, cmd := m.Update(runeKey("a"))
+		updatedModel := newModel.(todoModel)
+
+		if updatedModel.mode != todoAdding {
+			t.Errorf("aキー後は追加モードであるべき、実際: %v", updatedModel.mode)
+		}
+		if len(updatedModel.items) != initialCount+1 {
+			t.Errorf("入力行が追加されるべき、実際: %d件", len(updatedModel.items))
+		}
+		if updatedModel.cursor != 1 {
+			t.Errorf("カーソルは追加行（1）にあるべき、実際: %d", updatedModel.cursor)
+		}
+		if cmd == nil {
+			t.Error("フォーカス用のコマンドが返されるべき")
+		}
+		if len(m.items) != initialCount {
+			t.Error("元のモデルのアイテムは変更されないべき")
+		}
+	})
+
+	t.Run("追加を確定", func(t *testing.T) {
+		store := &memoryTodoStore{}
+		m := newLoadedTodoModel(store)
+		m.cursor = 2
+
+		m = sendTodoKey(m, runeKey("o"))
+		m = typeTodoText(m, "牛乳を買う")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m.mode != todoNormal {
+			t.Error("確定後は通常モードに戻るべき")
+		}
+		if m.items[3].title != "牛乳を買う" {
+			t.Errorf("カーソルの下に追加されるべき、実際: %q", m.items[3].title)
+		}
+		if store.saved != 1 {
+			t.Errorf("確定時に保存されるべき、実際: %d回", store.saved)
+		}
+	})
+
+	t.Run("追加中のqキーは文字として入力される", func(t *testing.T) {
+		m := NewTodoModel()
+		m = sendTodoKey(m, runeKey("a"))
+
+		m = sendTodoKey(m, runeKey("q"))
+
+		if m.mode != todoAdding {
+			t.Error("入力中のqキーで追加モードを抜けるべきでない")
+		}
+		if m.input.Value() != "q" {
+			t.Errorf("qが入力されるべき、実際: %q", m.input.Value())
+		}
+	})
+
+	t.Run("追加をEscでキャンセル", func(t *testing.T) {
+		m := NewTodoModel()
+		initialCount := len(m.items)
+
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "途中")
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = newModel.(todoModel)
+
+		if cmd != nil {
+			t.Error("入力中のEscでは終了すべきでない")
+		}
+		if m.mode != todoNormal {
+			t.Error("キャンセル後は通常モードに戻るべき")
+		}
+		if len(m.items) != initialCount {
+			t.Errorf("キャンセル後はアイテム数が元に戻るべき、実際: %d件", len(m.items))
+		}
+		if m.cursor != 0 {
+			t.Errorf("カーソルは元の位置に戻るべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("空のタイトルは追加されない", func(t *testing.T) {
+		m := NewTodoModel()
+		initialCount := len(m.items)
+
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "   ")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if len(m.items) != initialCount {
+			t.Errorf("空のタイトルは追加されないべき、実際: %d件", len(m.items))
+		}
+	})
+
+	t.Run("空のリストへの追加", func(t *testing.T) {
+		m := NewTodoModel()
+		m.items = nil
+
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "最初のTODO")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if len(m.items) != 1 || m.items[0].title != "最初のTODO" {
+			t.Errorf("空のリストに追加されるべき、実際: %+v", m.items)
+		}
+	})
+
+	t.Run("eキーで編集", func(t *testing.T) {
+		m := NewTodoModel()
+		m.cursor = 1
+		original := m.items[1].title
+
+		m = sendTodoKey(m, runeKey("e"))
+		if m.mode != todoEditing {
+			t.Fatalf("eキー後は編集モードであるべき、実際: %v", m.mode)
+		}
+		if m.input.Value() != original {
+			t.Errorf("入力欄に現在のタイトルが入るべき、実際: %q", m.input.Value())
+		}
+
+		m = typeTodoText(m, "（済）")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m.items[1].title != original+"（済）" {
+			t.Errorf("タイトルが更新されるべき、実際: %q", m.items[1].title)
+		}
+	})
+
+	t.Run("編集をEscでキャンセル", func(t *testing.T) {
+		m := NewTodoModel()
+		original := m.items[0].title
+
+		m = sendTodoKey(m, runeKey("e"))
+		m = typeTodoText(m, "変更")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+		if m.items[0].title != original {
+			t.Errorf("キャンセル時はタイトルが変わらないべき、実際: %q", m.items[0].title)
+		}
+	})
+
+	t.Run("ddで削除", func(t *testing.T) {
+		store := &memoryTodoStore{}
+		m := newLoadedTodoModel(store)
+		m.cursor = 1
+		deletedTitle := m.items[1].title
+		initialCount := len(m.items)
+
+		m = sendTodoKey(m, runeKey("d"))
+		if len(m.items) != initialCount {
+			t.Fatal("1回目のdでは削除されないべき")
+		}
+		m = sendTodoKey(m, runeKey("d"))
+
+		if len(m.items) != initialCount-1 {
+			t.Errorf("ddで1件削除されるべき、実際: %d件", len(m.items))
+		}
+		if m.items[1].title == deletedTitle {
+			t.Error("カーソル位置のアイテムが削除されるべき")
+		}
+		if store.saved != 1 {
+			t.Errorf("削除時に保存されるべき、実際: %d回", store.saved)
+		}
+	})
+
+	t.Run("dの後に別のキーで削除を中断", func(t *testing.T) {
+		m := NewTodoModel()
+		initialCount := len(m.items)
+
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		if len(m.items) != initialCount {
+			t.Errorf("d j d では削除されないべき、実際: %d件", len(m.items))
+		}
+	})
+
+	t.Run("末尾のアイテムを削除するとカーソルが前に移動", func(t *testing.T) {
+		m := NewTodoModel()
+		m.cursor = len(m.items) - 1
+
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		if m.cursor != len(m.items)-1 {
+			t.Errorf("カーソルは新しい末尾にあるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("uキーで削除を元に戻す", func(t *testing.T) {
+		m := NewTodoModel()
+		original := append([]todoItem(nil), m.items...)
+
+		m.cursor = 2
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+		m.cursor = 4
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		m = sendTodoKey(m, runeKey("u"))
+		m = sendTodoKey(m, runeKey("u"))
+
+		if len(m.items) != len(original) {
+			t.Fatalf("アイテム数が元に戻るべき、実際: %d件", len(m.items))
+		}
+		for i := range original {
+			if m.items[i].title != original[i].title {
+				t.Errorf("アイテム%dの位置が元に戻るべき: 期待 %q, 実際 %q", i, original[i].title, m.items[i].title)
+			}
+		}
+		if m.cursor != 2 {
+			t.Errorf("カーソルは復元したアイテムにあるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("削除して元に戻すとMarkdownの見出しも元の位置に戻る", func(t *testing.T) {
+		input := "## Work\n- [ ] A\n- [ ] B\n"
+		codec := markdownTodoCodec{}
+		doc := codec.decode([]byte(input))
+		m := newTodoTestModel(doc.items...)
+		m.sortMode = sortManual
+
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+		if got := string(codec.encode(todoDocument{items: m.items})); got != "## Work\n- [ ] B\n" {
+			t.Errorf("削除しても見出しは残るべき、実際:\n%s", got)
+		}
+		m = sendTodoKey(m, runeKey("u"))
+
+		if got := string(codec.encode(todoDocument{items: m.items, trailer: doc.trailer})); got != input {
+			t.Errorf("元に戻すと内容が一致するべき:\n期待:\n%s\n実際:\n%s", input, got)
+		}
+	})
+
+	t.Run("削除履歴がない場合のuキー", func(t *testing.T) {
+		m := NewTodoModel()
+		initialCount := len(m.items)
+
+		m = sendTodoKey(m, runeKey("u"))
+
+		if len(m.items) != initialCount {
+			t.Error("削除履歴がない場合は何も変わらないべき")
+		}
+	})
+
+	t.Run("追加時にビューポートが調整される", func(t *testing.T) {
+		m := NewTodoModel()
+		m.height = 3
+		m.cursor = 2
+
+		m = sendTodoKey(m, runeKey("a"))
+
+		if m.cursor < m.viewport || m.cursor >= m.viewport+m.height {
+			t.Errorf("カーソルはビューポート内にあるべき: cursor=%d viewport=%d", m.cursor, m.viewport)
+		}
+	})
+}
+
+func TestTodoModelEditView(t *testing.T) {
+	t.Run("入力中の行にtextinputが表示される", func(t *testing.T) {
+		m := NewTodoModel()
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "入力中のTODO")
+
+		view := m.View()
+		if !contains(view, "入力中のTODO") {
+			t.Error("入力中のテキストが表示されるべき")
+		}
+		if !contains(view, "Esc: キャンセル") {
+			t.Error("入力モードのヘルプが表示されるべき")
+		}
+	})
+
+	t.Run("空のリストの表示", func(t *testing.T) {
+		m := NewTodoModel()
+		m.items = nil
+
+		if !contains(m.View(), "アイテムがありません") {
+			t.Error("空のリストであることが表示されるべき")
+		}
+	})
+}
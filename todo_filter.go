@@ -0,0 +1,215 @@
+package main
+
+import (
+	"cmp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 並び順
+type todoSortMode int
+
+const (
+	sortByPriority todoSortMode = iota // 優先度 → 期日
+	sortByDue                          // 期日 → 優先度
+	sortByCreated                      // 作成日時
+	sortByTitle                        // タイトルのアルファベット順
+	sortManual                         // 手動（items の順）
+	todoSortModeCount
+)
+
+// 並び順の表示名
+var todoSortModeNames = map[todoSortMode]string{
+	sortByPriority: "優先度",
+	sortByDue:      "期日",
+	sortByCreated:  "作成順",
+	sortByTitle:    "名前順",
+	sortManual:     "手動",
+}
+
+// 保存時の並び順の名前
+var todoSortModeKeys = map[todoSortMode]string{
+	sortByPriority: "priority",
+	sortByDue:      "due",
+	sortByCreated:  "created",
+	sortByTitle:    "title",
+	sortManual:     "manual",
+}
+
+// 保存された並び順の名前を解釈（不明な場合は優先度順）
+func parseSortMode(s string) todoSortMode {
+	for mode, key := range todoSortModeKeys {
+		if key == s {
+			return mode
+		}
+	}
+	return sortByPriority
+}
+
+// String - 表示名
+func (s todoSortMode) String() string {
+	return todoSortModeNames[s]
+}
+
+// 次の並び順
+func (s todoSortMode) next() todoSortMode {
+	return (s + 1) % todoSortModeCount
+}
+
+// 期日の比較（期日なしは後ろ）
+func compareDue(a, b todoItem) int {
+	switch {
+	case a.due.IsZero() && b.due.IsZero():
+		return 0
+	case a.due.IsZero():
+		return 1
+	case b.due.IsZero():
+		return -1
+	}
+	return a.due.Compare(b.due)
+}
+
+// 並び順に従ってアイテムを比較（同順位は元の順序を保つ）
+func (s todoSortMode) compare(a, b todoItem) int {
+	switch s {
+	case sortByDue:
+		return cmp.Or(compareDue(a, b), cmp.Compare(b.priority, a.priority))
+	case sortByCreated:
+		return a.created.Compare(b.created)
+	case sortByTitle:
+		return cmp.Compare(strings.ToLower(a.title), strings.ToLower(b.title))
+	case sortManual:
+		return 0
+	default:
+		return compareTodoItems(a, b)
+	}
+}
+
+// fuzzyMatch - pattern の文字が text に順番通り含まれるか（大文字小文字は区別しない）
+func fuzzyMatch(pattern, text string) bool {
+	target := []rune(strings.ToLower(text))
+	pos := 0
+	for _, r := range strings.ToLower(pattern) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for pos < len(target) && target[pos] != r {
+			pos++
+		}
+		if pos == len(target) {
+			return false
+		}
+		pos++
+	}
+	return true
+}
+
+// フィルター文字列に一致するか（"#" で始まる場合はタグのみを対象にする）
+func (item todoItem) matches(filter string) bool {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return true
+	}
+
+	tagOnly := strings.HasPrefix(filter, "#")
+	if !tagOnly && fuzzyMatch(filter, item.title) {
+		return true
+	}
+	pattern := strings.TrimPrefix(filter, "#")
+	for _, tag := range item.tags {
+		if fuzzyMatch(pattern, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// フィルター入力用のtextinputを生成
+func newTodoFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "タイトルまたは #タグ"
+	ti.Prompt = "/"
+	ti.Width = 30
+	return ti
+}
+
+// フィルター入力を開始
+func (m todoModel) startFiltering() (todoModel, tea.Cmd) {
+	m.mode = todoFiltering
+	m.filterInput.CursorEnd()
+	return m, m.filterInput.Focus()
+}
+
+// フィルター入力中のメッセージ処理（入力に合わせて絞り込みを更新する）
+func (m todoModel) updateFilter(msg tea.Msg) (todoModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.mode = todoNormal
+			m.filterInput.Blur()
+			return m, nil
+		case tea.KeyEsc:
+			m.mode = todoNormal
+			m.filterInput.Blur()
+			return m.clearFilter(), nil
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m = m.keepCursor(func(m todoModel) todoModel {
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		return m
+	})
+	return m, cmd
+}
+
+// フィルターを解除
+func (m todoModel) clearFilter() todoModel {
+	return m.keepCursor(func(m todoModel) todoModel {
+		m.filterInput.SetValue("")
+		return m
+	})
+}
+
+// 完了済みアイテムの表示を切り替え
+func (m todoModel) toggleHideCompleted() todoModel {
+	return m.keepCursor(func(m todoModel) todoModel {
+		m.hideCompleted = !m.hideCompleted
+		return m
+	})
+}
+
+// 並び順を切り替え
+func (m todoModel) cycleSortMode() todoModel {
+	return m.keepCursor(func(m todoModel) todoModel {
+		m.sortMode = m.sortMode.next()
+		return m
+	})
+}
+
+// 表示条件を変更した後もカーソルを同じアイテムに保つ
+// （アイテムが非表示になった場合はカーソルを範囲内に収める）
+func (m todoModel) keepCursor(change func(todoModel) todoModel) todoModel {
+	index := m.currentIndex()
+	m = change(m)
+	if index >= 0 {
+		return m.cursorToItem(index)
+	}
+	return m.clampCursor()
+}
+
+// カーソルを表示中の行の範囲内に収める
+func (m todoModel) clampCursor() todoModel {
+	if n := len(m.rows()); m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	return m.clampViewport()
+}
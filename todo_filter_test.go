@@ -0,0 +1,318 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 表示中の行のタイトル一覧
+func visibleTitles(m todoModel) []string {
+	var titles []string
+	for _, i := range m.rows() {
+		titles = append(titles, m.items[i].title)
+	}
+	return titles
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		text     string
+		expected bool
+	}{
+		{"", "anything", true},
+		{"bbl", "Bubble Tea", true},
+		{"BT", "bubble tea", true},
+		{"bt ea", "bubble tea", true},
+		{"tb", "bubble tea", false},
+		{"リスト", "リスト表示を作成する", true},
+		{"リ作", "リスト表示を作成する", true},
+		{"xyz", "bubble tea", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.text, func(t *testing.T) {
+			if result := fuzzyMatch(tt.pattern, tt.text); result != tt.expected {
+				t.Errorf("fuzzyMatch(%q, %q) = %v, 期待値: %v", tt.pattern, tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTodoItemMatches(t *testing.T) {
+	item := todoItem{title: "レポートを書く", tags: []string{"work", "urgent"}}
+
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{"", true},
+		{"レポ", true},
+		{"wrk", true},
+		{"#urg", true},
+		{"#レポ", false},
+		{"home", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			if result := item.matches(tt.filter); result != tt.expected {
+				t.Errorf("matches(%q) = %v, 期待値: %v", tt.filter, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTodoModelFilter(t *testing.T) {
+	newModel := func() todoModel {
+		return newTodoTestModel(
+			todoItem{title: "牛乳を買う", tags: []string{"home"}},
+			todoItem{title: "レポートを書く", tags: []string{"work"}},
+			todoItem{title: "会議の準備", tags: []string{"work"}, completed: true},
+			todoItem{title: "部屋の掃除", tags: []string{"home"}},
+		)
+	}
+
+	t.Run("/キーで絞り込み入力", func(t *testing.T) {
+		m := newModel()
+
+		m = sendTodoKey(m, runeKey("/"))
+		if m.mode != todoFiltering {
+			t.Fatalf("/キー後は絞り込みモードであるべき、実際: %v", m.mode)
+		}
+		m = typeTodoText(m, "#work")
+
+		expected := []string{"レポートを書く", "会議の準備"}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, expected) {
+			t.Errorf("入力中に絞り込まれるべき: 期待 %v, 実際 %v", expected, titles)
+		}
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.mode != todoNormal {
+			t.Error("Enter後は通常モードに戻るべき")
+		}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, expected) {
+			t.Errorf("Enter後も絞り込みが維持されるべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("絞り込み入力中のqキーは文字として入力される", func(t *testing.T) {
+		m := newModel()
+		m = sendTodoKey(m, runeKey("/"))
+
+		m = sendTodoKey(m, runeKey("q"))
+
+		if m.mode != todoFiltering {
+			t.Error("絞り込み入力中のqキーで絞り込みモードを抜けるべきでない")
+		}
+		if m.filterInput.Value() != "q" {
+			t.Errorf("qが入力されるべき、実際: %q", m.filterInput.Value())
+		}
+	})
+
+	t.Run("Escで絞り込みを解除", func(t *testing.T) {
+		m := newModel()
+		m = sendTodoKey(m, runeKey("/"))
+		m = typeTodoText(m, "牛乳")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+		if m.filterInput.Value() != "" {
+			t.Error("Escで絞り込みが解除されるべき")
+		}
+		if len(m.rows()) != len(m.items) {
+			t.Errorf("全アイテムが表示されるべき、実際: %d件", len(m.rows()))
+		}
+	})
+
+	t.Run("通常モードのEscは絞り込み中なら解除、そうでなければ終了", func(t *testing.T) {
+		m := newModel()
+		m.filterInput.SetValue("牛乳")
+
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = newModel.(todoModel)
+		if cmd != nil {
+			t.Error("絞り込み中のEscでは終了すべきでない")
+		}
+		if m.filterInput.Value() != "" {
+			t.Error("Escで絞り込みが解除されるべき")
+		}
+
+		_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if cmd == nil {
+			t.Error("絞り込みがない場合のEscは終了するべき")
+		}
+	})
+
+	t.Run("絞り込みでカーソルが同じアイテムに留まる", func(t *testing.T) {
+		m := newModel()
+		m.cursor = 3 // 部屋の掃除
+
+		m = sendTodoKey(m, runeKey("/"))
+		m = typeTodoText(m, "#home")
+
+		if m.items[m.currentIndex()].title != "部屋の掃除" {
+			t.Errorf("カーソルは部屋の掃除に留まるべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+		if m.cursor != 1 {
+			t.Errorf("絞り込み後の行は1であるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("一致しない場合のカーソル", func(t *testing.T) {
+		m := newModel()
+		m.cursor = 2
+
+		m = sendTodoKey(m, runeKey("/"))
+		m = typeTodoText(m, "zzz")
+
+		if m.cursor != 0 || m.currentIndex() != -1 {
+			t.Errorf("一致しない場合はカーソルが0でアイテムなし: cursor=%d index=%d", m.cursor, m.currentIndex())
+		}
+		if !strings.Contains(m.View(), "一致するアイテムがありません") {
+			t.Error("一致しないことが表示されるべき")
+		}
+	})
+
+	t.Run("絞り込み中の移動は表示中の行の範囲内", func(t *testing.T) {
+		m := newModel()
+		m.filterInput.SetValue("#home")
+
+		for i := 0; i < 5; i++ {
+			m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		}
+
+		if m.cursor != 1 {
+			t.Errorf("カーソルは最後の表示行（1）で止まるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("絞り込み中の追加", func(t *testing.T) {
+		m := newModel()
+		m.filterInput.SetValue("#work")
+
+		m = sendTodoKey(m, runeKey("a"))
+		if m.items[m.currentIndex()].title != "" {
+			t.Fatal("追加中のアイテムは絞り込みに関わらず表示されるべき")
+		}
+		m = typeTodoText(m, "新しい作業")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if len(m.items) != 5 {
+			t.Errorf("アイテムが追加されるべき、実際: %d件", len(m.items))
+		}
+		if m.currentIndex() < 0 {
+			t.Error("カーソルは表示中の行にあるべき")
+		}
+	})
+
+	t.Run("cキーで完了済みを非表示", func(t *testing.T) {
+		m := newModel()
+
+		m = sendTodoKey(m, runeKey("c"))
+
+		for _, title := range visibleTitles(m) {
+			if title == "会議の準備" {
+				t.Error("完了済みのアイテムは非表示になるべき")
+			}
+		}
+		if !strings.Contains(m.View(), "完了を非表示") {
+			t.Error("非表示中であることが表示されるべき")
+		}
+
+		m = sendTodoKey(m, runeKey("c"))
+		if len(m.rows()) != len(m.items) {
+			t.Error("もう一度cキーで全て表示されるべき")
+		}
+	})
+
+	t.Run("完了済み非表示中に完了にすると行が消える", func(t *testing.T) {
+		m := newModel()
+		m.hideCompleted = true
+		m.cursor = 2 // 部屋の掃除（最後の行）
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if len(m.rows()) != 2 {
+			t.Errorf("表示中の行は2になるべき、実際: %d", len(m.rows()))
+		}
+		if m.cursor != 1 {
+			t.Errorf("カーソルは範囲内に収まるべき、実際: %d", m.cursor)
+		}
+	})
+}
+
+func TestTodoModelSort(t *testing.T) {
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	newModel := func() todoModel {
+		return newTodoTestModel(
+			todoItem{title: "banana", priority: priorityLow, created: base.Add(2 * time.Hour)},
+			todoItem{title: "Apple", due: todoTestDate(10, 20), created: base.Add(3 * time.Hour)},
+			todoItem{title: "cherry", priority: priorityHigh, due: todoTestDate(10, 25), created: base},
+			todoItem{title: "date", due: todoTestDate(10, 16), created: base.Add(time.Hour)},
+		)
+	}
+
+	tests := []struct {
+		mode     todoSortMode
+		expected []string
+	}{
+		{sortByPriority, []string{"cherry", "banana", "date", "Apple"}},
+		{sortByDue, []string{"date", "Apple", "cherry", "banana"}},
+		{sortByCreated, []string{"cherry", "date", "banana", "Apple"}},
+		{sortByTitle, []string{"Apple", "banana", "cherry", "date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			m := newModel()
+			m.sortMode = tt.mode
+			if titles := visibleTitles(m); !reflect.DeepEqual(titles, tt.expected) {
+				t.Errorf("並び順が正しくない: 期待 %v, 実際 %v", tt.expected, titles)
+			}
+		})
+	}
+
+	t.Run("sキーで並び順を切り替え、カーソルが追従する", func(t *testing.T) {
+		m := newModel()
+		m.cursor = 0 // cherry
+
+		m = sendTodoKey(m, runeKey("s"))
+
+		if m.sortMode != sortByDue {
+			t.Errorf("並び順は期日になるべき、実際: %v", m.sortMode)
+		}
+		if m.items[m.currentIndex()].title != "cherry" {
+			t.Errorf("カーソルはcherryに留まるべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+		if !strings.Contains(m.View(), "並び順: 期日") {
+			t.Error("並び順が表示されるべき")
+		}
+	})
+
+	t.Run("並び順は一周する", func(t *testing.T) {
+		m := newModel()
+		for i := 0; i < int(todoSortModeCount); i++ {
+			m = sendTodoKey(m, runeKey("s"))
+		}
+		if m.sortMode != sortByPriority {
+			t.Errorf("一周して優先度に戻るべき、実際: %v", m.sortMode)
+		}
+	})
+
+	t.Run("追加したアイテムに作成日時が設定される", func(t *testing.T) {
+		m := newModel()
+
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "新規")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		item := m.items[m.currentIndex()]
+		if item.title != "新規" || !item.created.Equal(todoTestNow) {
+			t.Errorf("作成日時は現在時刻であるべき、実際: %+v", item)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TODOの優先度
+type todoPriority int
+
+const (
+	priorityNone todoPriority = iota
+	priorityLow
+	priorityMedium
+	priorityHigh
+)
+
+// 保存時の優先度名
+var priorityNames = map[todoPriority]string{
+	priorityNone:   "",
+	priorityLow:    "low",
+	priorityMedium: "medium",
+	priorityHigh:   "high",
+}
+
+// String - 保存用の名前
+func (p todoPriority) String() string {
+	return priorityNames[p]
+}
+
+// 一覧に表示する記号
+func (p todoPriority) marker() string {
+	return strings.Repeat("!", int(p))
+}
+
+// 次の優先度（high の次は none に戻る）
+func (p todoPriority) next() todoPriority {
+	return (p + 1) % (priorityHigh + 1)
+}
+
+// 名前から優先度を取得（不明な名前は none）
+func parsePriority(s string) todoPriority {
+	for p, name := range priorityNames {
+		if name != "" && name == strings.ToLower(s) {
+			return p
+		}
+	}
+	return priorityNone
+}
+
+// 期日の日付フォーマット
+const dueDateLayout = "2006-01-02"
+
+// 相対指定（in 3 days / +2w）
+var relativeDueRegex = regexp.MustCompile(`^(?:in\s+(\d+)\s*(days?|weeks?)|\+(\d+)([dw]))$`)
+
+// 曜日名（英語の省略形・正式名と日本語）
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "日": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "月": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday, "火": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "水": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday, "木": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "金": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "土": time.Saturday,
+}
+
+// その日の0時
+func startOfDay(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// 曜日名をパース（"金曜日" や "金曜" も受け付ける）
+func lookupWeekday(s string) (time.Weekday, bool) {
+	for _, suffix := range []string{"曜日", "曜"} {
+		if rest, ok := strings.CutSuffix(s, suffix); ok {
+			s = rest
+			break
+		}
+	}
+	wd, ok := weekdayNames[s]
+	return wd, ok
+}
+
+// parseDueDate - 自然言語の期日をパース
+//
+// today / tomorrow / "in 3 days" / "+2w" / "fri" / "next fri" / "2026-10-20" / "10/20"
+// などを受け付け、now を基準にその日の0時を返す。空文字は期日なし（ゼロ値）。
+func parseDueDate(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	today := startOfDay(now)
+
+	switch s {
+	case "":
+		return time.Time{}, nil
+	case "today", "tod", "今日":
+		return today, nil
+	case "tomorrow", "tom", "tmr", "明日":
+		return today.AddDate(0, 0, 1), nil
+	case "day after tomorrow", "明後日":
+		return today.AddDate(0, 0, 2), nil
+	case "next week", "来週":
+		// 来週の月曜日
+		days := (int(time.Monday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if m := relativeDueRegex.FindStringSubmatch(s); m != nil {
+		n, unit := m[1], m[2]
+		if n == "" {
+			n, unit = m[3], m[4]
+		}
+		count, _ := strconv.Atoi(n)
+		if strings.HasPrefix(unit, "w") {
+			count *= 7
+		}
+		return today.AddDate(0, 0, count), nil
+	}
+
+	// 曜日指定: "fri" は今日より後の直近、"next fri" は来週（月曜始まり）のその曜日
+	name, nextWeek := s, false
+	for _, prefix := range []string{"next ", "来週"} {
+		if rest, ok := strings.CutPrefix(s, prefix); ok {
+			name, nextWeek = strings.TrimSpace(rest), true
+		}
+	}
+	if wd, ok := lookupWeekday(name); ok {
+		if nextWeek {
+			// 今週の月曜日からの相対で計算
+			offset := (int(today.Weekday()) + 6) % 7
+			monday := today.AddDate(0, 0, -offset)
+			return monday.AddDate(0, 0, 7+(int(wd)+6)%7), nil
+		}
+		days := (int(wd) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	// 日付指定
+	s = strings.ReplaceAll(s, "/", "-")
+	if t, err := time.ParseInLocation("2006-1-2", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("1-2", s, now.Location()); err == nil {
+		// 年の省略時は今日以降の直近の日付
+		due := time.Date(today.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		if due.Before(today) {
+			due = due.AddDate(1, 0, 0)
+		}
+		return due, nil
+	}
+
+	return time.Time{}, fmt.Errorf("期日を解釈できません: %q", input)
+}
+
+// 期日の表示用文字列
+func formatDueDate(due, now time.Time) string {
+	today := startOfDay(now)
+	// 夏時間の切り替えで1日が24時間でない場合に備えて丸める
+	switch days := int(math.Round(due.Sub(today).Hours() / 24)); days {
+	case -1:
+		return "昨日"
+	case 0:
+		return "今日"
+	case 1:
+		return "明日"
+	}
+	if due.Year() == today.Year() {
+		return due.Format("1/2")
+	}
+	return due.Format("2006/1/2")
+}
+
+// タグ入力をパース（空白・カンマ区切り、先頭の # は省略可、重複は除外）
+func parseTags(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '　'
+	})
+
+	var tags []string
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		tag := strings.TrimLeft(f, "#")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// タグを入力欄用の文字列に戻す
+func formatTags(tags []string) string {
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用の固定時刻（2026-10-14 水曜日 15:00）
+var todoTestNow = time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+
+func todoTestDate(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseDueDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"today", todoTestDate(10, 14)},
+		{"今日", todoTestDate(10, 14)},
+		{"tomorrow", todoTestDate(10, 15)},
+		{"Tomorrow", todoTestDate(10, 15)},
+		{"明日", todoTestDate(10, 15)},
+		{"明後日", todoTestDate(10, 16)},
+		{"in 3 days", todoTestDate(10, 17)},
+		{"in 1 week", todoTestDate(10, 21)},
+		{"+2w", todoTestDate(10, 28)},
+		{"+10d", todoTestDate(10, 24)},
+		{"fri", todoTestDate(10, 16)},
+		{"friday", todoTestDate(10, 16)},
+		{"wed", todoTestDate(10, 21)},
+		{"next fri", todoTestDate(10, 23)},
+		{"next mon", todoTestDate(10, 19)},
+		{"next week", todoTestDate(10, 19)},
+		{"金曜", todoTestDate(10, 16)},
+		{"日曜日", todoTestDate(10, 18)},
+		{"来週月曜", todoTestDate(10, 19)},
+		{"2026-12-31", todoTestDate(12, 31)},
+		{"2026/11/3", todoTestDate(11, 3)},
+		{"10/20", todoTestDate(10, 20)},
+		{"1/5", time.Date(2027, 1, 5, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseDueDate(tt.input, todoTestNow)
+			if err != nil {
+				t.Fatalf("エラーが発生すべきでない: %v", err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("parseDueDate(%q) = %v, 期待値: %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("解釈できない入力", func(t *testing.T) {
+		for _, input := range []string{"someday", "next", "13/45", "in days"} {
+			if _, err := parseDueDate(input, todoTestNow); err == nil {
+				t.Errorf("%qはエラーになるべき", input)
+			}
+		}
+	})
+}
+
+func TestFormatDueDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		due      time.Time
+		expected string
+	}{
+		{"昨日", todoTestDate(10, 13), "昨日"},
+		{"今日", todoTestDate(10, 14), "今日"},
+		{"明日", todoTestDate(10, 15), "明日"},
+		{"同じ年", todoTestDate(12, 1), "12/1"},
+		{"別の年", time.Date(2027, 1, 5, 0, 0, 0, 0, time.Local), "2027/1/5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatDueDate(tt.due, todoTestNow); result != tt.expected {
+				t.Errorf("formatDueDate() = %s, 期待値: %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"work", []string{"work"}},
+		{"#work home", []string{"work", "home"}},
+		{"work, home,errand", []string{"work", "home", "errand"}},
+		{"仕事　買い物", []string{"仕事", "買い物"}},
+		{"work #work", []string{"work"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := parseTags(tt.input); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseTags(%q) = %v, 期待値: %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTodoPriority(t *testing.T) {
+	t.Run("優先度の循環", func(t *testing.T) {
+		p := priorityNone
+		expected := []todoPriority{priorityLow, priorityMedium, priorityHigh, priorityNone}
+		for _, e := range expected {
+			p = p.next()
+			if p != e {
+				t.Errorf("次の優先度は%vであるべき、実際: %v", e, p)
+			}
+		}
+	})
+
+	t.Run("名前との相互変換", func(t *testing.T) {
+		for _, p := range []todoPriority{priorityNone, priorityLow, priorityMedium, priorityHigh} {
+			if parsed := parsePriority(p.String()); parsed != p {
+				t.Errorf("parsePriority(%q) = %v, 期待値: %v", p.String(), parsed, p)
+			}
+		}
+		if parsePriority("HIGH") != priorityHigh {
+			t.Error("大文字の優先度名も解釈されるべき")
+		}
+		if parsePriority("unknown") != priorityNone {
+			t.Error("不明な優先度名はnoneになるべき")
+		}
+	})
+}
+
+// 固定時刻のTODOモデル
+func newTodoTestModel(items ...todoItem) todoModel {
+	m := NewTodoModel()
+	m.items = items
+	m.now = func() time.Time { return todoTestNow }
+	return m
+}
+
+func TestTodoModelRichItems(t *testing.T) {
+	t.Run("優先度と期日で並ぶ", func(t *testing.T) {
+		m := newTodoTestModel(
+			todoItem{title: "期日なし"},
+			todoItem{title: "来週", due: todoTestDate(10, 21)},
+			todoItem{title: "高優先度", priority: priorityHigh},
+			todoItem{title: "明日", due: todoTestDate(10, 15)},
+			todoItem{title: "中優先度・明日", priority: priorityMedium, due: todoTestDate(10, 15)},
+		)
+
+		var titles []string
+		for _, i := range m.rows() {
+			titles = append(titles, m.items[i].title)
+		}
+		expected := []string{"高優先度", "中優先度・明日", "明日", "来週", "期日なし"}
+		if !reflect.DeepEqual(titles, expected) {
+			t.Errorf("表示順が正しくない: 期待 %v, 実際 %v", expected, titles)
+		}
+	})
+
+	t.Run("pキーで優先度を切り替え、カーソルが追従する", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A"}, todoItem{title: "B"})
+		m.cursor = 1
+
+		m = sendTodoKey(m, runeKey("p"))
+
+		if m.items[1].priority != priorityLow {
+			t.Errorf("優先度はlowになるべき、実際: %v", m.items[1].priority)
+		}
+		if m.cursor != 0 || m.currentIndex() != 1 {
+			t.Errorf("カーソルは先頭に移動したBにあるべき: cursor=%d index=%d", m.cursor, m.currentIndex())
+		}
+	})
+
+	t.Run("Dキーで期日を設定", func(t *testing.T) {
+		store := &memoryTodoStore{}
+		m := newTodoTestModel(todoItem{title: "A"})
+		m.store = store
+
+		m = sendTodoKey(m, runeKey("D"))
+		if m.mode != todoEditingDue {
+			t.Fatalf("Dキー後は期日入力モードであるべき、実際: %v", m.mode)
+		}
+		m = typeTodoText(m, "next fri")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if !m.items[0].due.Equal(todoTestDate(10, 23)) {
+			t.Errorf("期日は10/23になるべき、実際: %v", m.items[0].due)
+		}
+		if store.saved != 1 {
+			t.Errorf("期日設定時に保存されるべき、実際: %d回", store.saved)
+		}
+	})
+
+	t.Run("不正な期日は入力モードのままエラー表示", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A"})
+
+		m = sendTodoKey(m, runeKey("D"))
+		m = typeTodoText(m, "someday")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m.mode != todoEditingDue {
+			t.Error("不正な期日では入力モードのままであるべき")
+		}
+		if m.err == nil || !strings.Contains(m.View(), "期日を解釈できません") {
+			t.Error("エラーが表示されるべき")
+		}
+	})
+
+	t.Run("空の期日で解除", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A", due: todoTestDate(10, 20)})
+
+		m = sendTodoKey(m, runeKey("D"))
+		m.input.SetValue("")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if !m.items[0].due.IsZero() {
+			t.Errorf("期日が解除されるべき、実際: %v", m.items[0].due)
+		}
+	})
+
+	t.Run("tキーでタグを設定", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A", tags: []string{"old"}})
+
+		m = sendTodoKey(m, runeKey("t"))
+		if m.input.Value() != "old" {
+			t.Errorf("入力欄に現在のタグが入るべき、実際: %q", m.input.Value())
+		}
+		m.input.SetValue("#work home")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if !reflect.DeepEqual(m.items[0].tags, []string{"work", "home"}) {
+			t.Errorf("タグが設定されるべき、実際: %v", m.items[0].tags)
+		}
+	})
+
+	t.Run("nキーでメモを編集（Enterは改行、Ctrl+Sで確定）", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A"})
+
+		m = sendTodoKey(m, runeKey("n"))
+		if m.mode != todoEditingNote {
+			t.Fatalf("nキー後はメモ入力モードであるべき、実際: %v", m.mode)
+		}
+		m = typeTodoText(m, "1行目")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+		m = typeTodoText(m, "2行目")
+		if m.mode != todoEditingNote {
+			t.Fatal("Enterではメモ入力モードを抜けないべき")
+		}
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+
+		if m.items[0].note != "1行目\n2行目" {
+			t.Errorf("複数行のメモが保存されるべき、実際: %q", m.items[0].note)
+		}
+		if m.mode != todoNormal {
+			t.Error("Ctrl+S後は通常モードに戻るべき")
+		}
+	})
+
+	t.Run("メモの編集をEscでキャンセル", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A", note: "元のメモ"})
+
+		m = sendTodoKey(m, runeKey("n"))
+		m = typeTodoText(m, "追記")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+		if m.items[0].note != "元のメモ" {
+			t.Errorf("キャンセル時はメモが変わらないべき、実際: %q", m.items[0].note)
+		}
+	})
+
+	t.Run("詳細項目の保存と読み込み", func(t *testing.T) {
+		store := newJSONTodoStore(filepath.Join(t.TempDir(), "todo.json"))
+		items := []todoItem{{
+			title:    "詳細あり",
+			due:      todoTestDate(10, 20),
+			priority: priorityMedium,
+			tags:     []string{"work", "仕事"},
+			note:     "1行目\n2行目",
+		}}
+
+		if err := store.Save(todoListSnapshot{items: items}); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := store.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.items, items) {
+			t.Errorf("詳細項目が保持されるべき: 期待 %+v, 実際 %+v", items, loaded.items)
+		}
+	})
+}
+
+func TestTodoModelRichItemsView(t *testing.T) {
+	t.Run("タグ・期日・優先度の表示", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{
+			title:    "A",
+			due:      todoTestDate(10, 15),
+			priority: priorityHigh,
+			tags:     []string{"work"},
+		})
+		view := m.View()
+
+		for _, expected := range []string{"#work", "📅 明日", "!!!"} {
+			if !strings.Contains(view, expected) {
+				t.Errorf("ビューに「%s」が含まれているべき", expected)
+			}
+		}
+	})
+
+	t.Run("期限切れの判定", func(t *testing.T) {
+		overdue := todoItem{title: "A", due: todoTestDate(10, 13)}
+		if !overdue.overdue(todoTestNow) {
+			t.Error("昨日が期日のアイテムは期限切れであるべき")
+		}
+		today := todoItem{title: "B", due: todoTestDate(10, 14)}
+		if today.overdue(todoTestNow) {
+			t.Error("今日が期日のアイテムは期限切れでないべき")
+		}
+		done := todoItem{title: "C", due: todoTestDate(10, 13), completed: true}
+		if done.overdue(todoTestNow) {
+			t.Error("完了済みのアイテムは期限切れでないべき")
+		}
+	})
+
+	t.Run("選択中のアイテムのメモを表示", func(t *testing.T) {
+		m := newTodoTestModel(todoItem{title: "A", note: "メモの内容"}, todoItem{title: "B", note: "別のメモ"})
+		view := m.View()
+
+		if !strings.Contains(view, "メモの内容") {
+			t.Error("選択中のアイテムのメモが表示されるべき")
+		}
+		if strings.Contains(view, "別のメモ") {
+			t.Error("選択していないアイテムのメモは表示されないべき")
+		}
+	})
+}
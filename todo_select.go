@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 複数選択は todoItem.marked で表す。並び替えや追加でインデックスが変わっても
+// 選択がアイテムに付いて回るようにするため。
+// 範囲選択（V）中はアンカーとカーソルの間の行も選択中として扱い、
+// 確定するか一括操作を行う時点で marked に反映する。
+
+// 選択中のアイテムを移動できない位置
+var errMoveIntoSelection = errors.New("選択中のアイテムの中には移動できません")
+
+// 範囲選択を開始
+func (m todoModel) startVisual() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	m.mode = todoVisual
+	m.visualAnchor = i
+	return m
+}
+
+// 範囲選択の行の範囲（from <= to、範囲選択中でない場合は ok=false）
+func (m todoModel) visualRange() (from, to int, ok bool) {
+	if m.mode != todoVisual {
+		return 0, 0, false
+	}
+	anchor := slices.Index(m.rows(), m.visualAnchor)
+	if anchor < 0 {
+		// アンカーが折りたたまれた場合はカーソルの行のみ
+		anchor = m.cursor
+	}
+	return min(anchor, m.cursor), max(anchor, m.cursor), true
+}
+
+// 行が選択中か（範囲選択中の範囲も含む）
+func (m todoModel) isSelected(row int) bool {
+	if from, to, ok := m.visualRange(); ok && from <= row && row <= to {
+		return true
+	}
+	return m.items[m.rows()[row]].marked
+}
+
+// 範囲選択の範囲を選択に反映して通常モードへ戻る
+func (m todoModel) fixVisual() todoModel {
+	if from, to, ok := m.visualRange(); ok {
+		for _, i := range m.rows()[from : to+1] {
+			m.items[i].marked = true
+		}
+	}
+	m.mode = todoNormal
+	return m
+}
+
+// 選択中のアイテムのインデックス（表示中のもののみ、items の順）
+func (m todoModel) selection() []int {
+	var indices []int
+	for _, i := range m.rows() {
+		if m.items[i].marked {
+			indices = append(indices, i)
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// 選択中のアイテムがあるか
+func (m todoModel) hasSelection() bool {
+	return len(m.selection()) > 0
+}
+
+// 選択中のアイテムのうち、他の選択中のアイテムの子孫でないもの
+func (m todoModel) selectionRoots() []int {
+	var roots []int
+	end := -1
+	for _, i := range m.selection() {
+		if i < end {
+			continue
+		}
+		roots = append(roots, i)
+		end = m.subtreeEnd(i)
+	}
+	return roots
+}
+
+// 選択を全て解除
+func (m todoModel) clearSelection() todoModel {
+	for i := range m.items {
+		m.items[i].marked = false
+	}
+	m.mode = todoNormal
+	return m
+}
+
+// カーソル位置のアイテムの選択を切り替えて次の行へ
+func (m todoModel) toggleMark() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	m.items[i].marked = !m.items[i].marked
+	return m.moveCursorDown()
+}
+
+// 範囲選択中のメッセージ処理
+func (m todoModel) updateVisual(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.Type {
+	case tea.KeyUp:
+		m = m.moveCursorUp()
+	case tea.KeyDown:
+		m = m.moveCursorDown()
+	case tea.KeyEnter, tea.KeySpace:
+		m = m.fixVisual().bulkToggle(false).save()
+	case tea.KeyEsc:
+		// 範囲選択だけを取り消す（v で付けた選択は残す）
+		m.mode = todoNormal
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyRunes:
+		switch string(key.Runes) {
+		case "j":
+			m = m.moveCursorDown()
+		case "k":
+			m = m.moveCursorUp()
+		case "V", "v":
+			m = m.fixVisual()
+		case "x":
+			m = m.fixVisual().bulkToggle(true).save()
+		case "d":
+			m = m.fixVisual().bulkDelete().save()
+		case "t":
+			return m.fixVisual().startEditing(todoEditingTags)
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// 選択中のアイテムの完了状態を一括で切り替え
+// 未完了のものが1つでもあれば全て完了に、全て完了済みなら全て未完了にする
+func (m todoModel) bulkToggle(withChildren bool) todoModel {
+	var indices []int
+	if withChildren {
+		for _, i := range m.selectionRoots() {
+			for j := i; j < m.subtreeEnd(i); j++ {
+				indices = append(indices, j)
+			}
+		}
+	} else {
+		indices = m.selection()
+	}
+
+	completed := slices.ContainsFunc(indices, func(i int) bool { return !m.items[i].completed })
+	for _, i := range indices {
+		m.items[i] = m.items[i].withCompleted(completed, m.now())
+	}
+	return m.clearSelection().clampCursor()
+}
+
+// 選択中のアイテムを子孫ごと一括で削除（u で一度に元に戻せる）
+func (m todoModel) bulkDelete() todoModel {
+	roots := m.selectionRoots()
+	if len(roots) == 0 {
+		return m
+	}
+
+	// 後ろから削除すると前のアイテムのインデックスが変わらない
+	var group []deletedTodo
+	for _, i := range slices.Backward(roots) {
+		var block deletedTodo
+		m, block = m.removeSubtree(i)
+		group = append(group, block)
+	}
+	m.deleted = append(m.deleted, group)
+	return m.clearSelection().clampCursor()
+}
+
+// 選択中のアイテムのタグを一括で変更（"work -home" で work を追加し home を削除）
+func (m todoModel) bulkRetag(input string) todoModel {
+	var add, remove []string
+	for _, f := range strings.Fields(input) {
+		if tag, ok := strings.CutPrefix(f, "-"); ok {
+			remove = append(remove, parseTags(tag)...)
+		} else {
+			add = append(add, parseTags(strings.TrimPrefix(f, "+"))...)
+		}
+	}
+
+	for _, i := range m.selection() {
+		tags := slices.DeleteFunc(slices.Clone(m.items[i].tags), func(tag string) bool {
+			return slices.Contains(remove, tag)
+		})
+		for _, tag := range add {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			tags = nil
+		}
+		m.items[i].tags = tags
+	}
+	return m.clearSelection()
+}
+
+// 選択中のアイテムを子孫ごとカーソル位置のアイテムの後ろ（兄弟）へ移動
+func (m todoModel) bulkMove() todoModel {
+	target := m.currentIndex()
+	roots := m.selectionRoots()
+	if target < 0 || len(roots) == 0 {
+		return m
+	}
+	for _, i := range roots {
+		if i <= target && target < m.subtreeEnd(i) {
+			m.err = errMoveIntoSelection
+			return m
+		}
+	}
+
+	// 移動先より前から取り除く分だけ移動先のインデックスがずれる
+	depth := m.items[target].depth
+	shifted := target
+	for _, i := range roots {
+		if i < target {
+			shifted -= m.subtreeEnd(i) - i
+		}
+	}
+
+	var blocks [][]todoItem
+	for _, i := range slices.Backward(roots) {
+		var block deletedTodo
+		m, block = m.removeSubtree(i)
+		// 移動先の兄弟になるように階層をずらす
+		shift := depth - block.items[0].depth
+		for j := range block.items {
+			block.items[j].depth += shift
+			block.items[j].marked = false
+		}
+		blocks = append(blocks, block.items)
+	}
+
+	var moved []todoItem
+	for _, block := range slices.Backward(blocks) {
+		moved = append(moved, block...)
+	}
+	index := m.subtreeEnd(shifted)
+	m.items = slices.Insert(m.items, index, moved...)
+	m.err = nil
+	return m.clearSelection().cursorToItem(index)
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 選択中のアイテムのタイトル一覧
+func markedTitles(m todoModel) []string {
+	var titles []string
+	for _, i := range m.selection() {
+		titles = append(titles, m.items[i].title)
+	}
+	return titles
+}
+
+func newTodoSelectModel() todoModel {
+	return newTodoTestModel(
+		todoItem{title: "A"},
+		todoItem{title: "B", tags: []string{"home"}},
+		todoItem{title: "C", completed: true},
+		todoItem{title: "D", tags: []string{"work"}},
+		todoItem{title: "E"},
+	)
+}
+
+func TestTodoModelSelect(t *testing.T) {
+	t.Run("vキーで選択して次の行へ", func(t *testing.T) {
+		m := newTodoSelectModel()
+
+		m = sendTodoKey(m, runeKey("v"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, runeKey("v"))
+
+		if titles := markedTitles(m); !reflect.DeepEqual(titles, []string{"A", "C"}) {
+			t.Errorf("AとCが選択されるべき、実際: %v", titles)
+		}
+		if m.cursor != 3 {
+			t.Errorf("カーソルは3行目に進むべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("Vキーで範囲選択", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.cursor = 1
+
+		m = sendTodoKey(m, runeKey("V"))
+		if m.mode != todoVisual {
+			t.Fatalf("範囲選択モードになるべき、実際: %v", m.mode)
+		}
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, runeKey("k"))
+
+		if !strings.Contains(m.View(), "範囲選択: 2行") {
+			t.Error("範囲選択の行数が表示されるべき")
+		}
+
+		m = sendTodoKey(m, runeKey("V"))
+		if m.mode != todoNormal {
+			t.Error("もう一度Vキーで通常モードに戻るべき")
+		}
+		if titles := markedTitles(m); !reflect.DeepEqual(titles, []string{"B", "C"}) {
+			t.Errorf("BとCが選択されるべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("上方向の範囲選択", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.cursor = 3
+
+		m = sendTodoKey(m, runeKey("V"))
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendTodoKey(m, runeKey("V"))
+
+		if titles := markedTitles(m); !reflect.DeepEqual(titles, []string{"C", "D"}) {
+			t.Errorf("CとDが選択されるべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("範囲選択中のEscは範囲のみ取り消す", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m = sendTodoKey(m, runeKey("v")) // A
+
+		m = sendTodoKey(m, runeKey("V"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+		if m.mode != todoNormal {
+			t.Error("Escで通常モードに戻るべき")
+		}
+		if titles := markedTitles(m); !reflect.DeepEqual(titles, []string{"A"}) {
+			t.Errorf("vキーの選択は残るべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("通常モードのEscで選択を解除", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[0].marked = true
+
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = newModel.(todoModel)
+
+		if cmd != nil {
+			t.Error("選択中のEscでは終了すべきでない")
+		}
+		if m.hasSelection() {
+			t.Error("Escで選択が解除されるべき")
+		}
+	})
+
+	t.Run("選択を並び替えても同じアイテムに残る", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[4].marked = true
+
+		m = sendTodoKey(m, runeKey("s"))
+		m = sendTodoKey(m, runeKey("s"))
+		m = sendTodoKey(m, runeKey("s"))
+
+		if titles := markedTitles(m); !reflect.DeepEqual(titles, []string{"E"}) {
+			t.Errorf("Eが選択されたままであるべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("選択の表示", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[0].marked = true
+		m.items[1].marked = true
+
+		view := m.View()
+
+		if !strings.Contains(view, ">●") {
+			t.Error("カーソル行の選択はカーソルと区別して表示されるべき")
+		}
+		if !strings.Contains(view, " ●") {
+			t.Error("選択中の行に印が表示されるべき")
+		}
+		if !strings.Contains(view, "選択: 2件") {
+			t.Error("選択件数が表示されるべき")
+		}
+	})
+}
+
+func TestTodoModelBulk(t *testing.T) {
+	t.Run("一括で完了・未完了", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[0].marked = true
+		m.items[2].marked = true
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeySpace})
+
+		if !m.items[0].completed || !m.items[2].completed {
+			t.Error("未完了が含まれる場合は全て完了になるべき")
+		}
+		if !m.items[0].done.Equal(todoTestNow) {
+			t.Error("完了日時が記録されるべき")
+		}
+		if m.items[1].completed {
+			t.Error("選択していないアイテムは変更されないべき")
+		}
+		if m.hasSelection() {
+			t.Error("一括操作後は選択が解除されるべき")
+		}
+
+		m.items[0].marked = true
+		m.items[2].marked = true
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if m.items[0].completed || m.items[2].completed {
+			t.Error("全て完了済みの場合は全て未完了になるべき")
+		}
+	})
+
+	t.Run("範囲選択から一括で完了", func(t *testing.T) {
+		m := newTodoSelectModel()
+
+		m = sendTodoKey(m, runeKey("V"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeySpace})
+
+		if !m.items[0].completed || !m.items[1].completed || m.items[3].completed {
+			t.Error("範囲内のみ完了になるべき")
+		}
+		if m.mode != todoNormal {
+			t.Error("一括操作後は通常モードに戻るべき")
+		}
+	})
+
+	t.Run("xキーで子孫ごと一括完了", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].marked = true
+		m.items[4].marked = true
+
+		m = sendTodoKey(m, runeKey("x"))
+
+		for _, item := range m.items {
+			if !item.completed {
+				t.Errorf("%sは完了になるべき", item.title)
+			}
+		}
+	})
+
+	t.Run("一括で削除し、uで一度に元に戻す", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[1].marked = true
+		m.items[3].marked = true
+
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"A", "C", "E"}) {
+			t.Errorf("BとDが削除されるべき、実際: %v", titles)
+		}
+
+		m = sendTodoKey(m, runeKey("u"))
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"A", "B", "C", "D", "E"}) {
+			t.Errorf("一度のuで全て元に戻るべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("範囲選択中のdキーで削除", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.cursor = 3
+
+		m = sendTodoKey(m, runeKey("V"))
+		m = sendTodoKey(m, runeKey("j"))
+		m = sendTodoKey(m, runeKey("d"))
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"A", "B", "C"}) {
+			t.Errorf("DとEが削除されるべき、実際: %v", titles)
+		}
+		if m.cursor != 2 {
+			t.Errorf("カーソルは範囲内に収まるべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("親と子を選択して削除しても二重に削除しない", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].marked = true
+		m.items[2].marked = true
+
+		m = m.bulkDelete()
+
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, []string{"親B"}) {
+			t.Errorf("親Aの子孫ごと削除されるべき、実際: %v", outline)
+		}
+	})
+
+	t.Run("一括でタグを追加・削除", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[1].marked = true
+		m.items[3].marked = true
+
+		m = sendTodoKey(m, runeKey("t"))
+		if m.input.Value() != "" {
+			t.Errorf("一括のタグ入力は空で始まるべき、実際: %q", m.input.Value())
+		}
+		m = typeTodoText(m, "urgent -home #work")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if !reflect.DeepEqual(m.items[1].tags, []string{"urgent", "work"}) {
+			t.Errorf("Bのタグが正しくない: %v", m.items[1].tags)
+		}
+		if !reflect.DeepEqual(m.items[3].tags, []string{"work", "urgent"}) {
+			t.Errorf("Dのタグが正しくない: %v", m.items[3].tags)
+		}
+		if m.items[0].tags != nil {
+			t.Error("選択していないアイテムは変更されないべき")
+		}
+		if m.hasSelection() {
+			t.Error("一括操作後は選択が解除されるべき")
+		}
+	})
+
+	t.Run("Mキーで選択をカーソルの後ろへ移動", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.items[0].marked = true
+		m.items[2].marked = true
+		m.cursor = 3 // D
+
+		m = sendTodoKey(m, runeKey("M"))
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"B", "D", "A", "C", "E"}) {
+			t.Errorf("AとCがDの後ろに移動するべき、実際: %v", titles)
+		}
+		if m.items[m.currentIndex()].title != "A" {
+			t.Errorf("カーソルは移動した先頭のアイテムに移るべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+		if m.hasSelection() {
+			t.Error("移動後は選択が解除されるべき")
+		}
+	})
+
+	t.Run("子孫ごと別の階層へ移動", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[1].marked = true // 子A1（孫A1aを持つ）
+		m = m.cursorToItem(4)    // 親B
+
+		m = m.bulkMove()
+
+		expected := []string{"親A", "-子A2", "親B", "子A1", "-孫A1a"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("階層を保ったまま移動するべき: 期待 %v, 実際 %v", expected, outline)
+		}
+	})
+
+	t.Run("選択の中へは移動できない", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].marked = true
+		m = m.cursorToItem(2) // 孫A1a
+
+		m = m.bulkMove()
+
+		if m.err != errMoveIntoSelection {
+			t.Errorf("エラーになるべき、実際: %v", m.err)
+		}
+		if outline := treeOutline(m); outline[0] != "親A" || len(outline) != 5 {
+			t.Errorf("アイテムは変更されないべき、実際: %v", outline)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ktny/bubbletea-learning/pkg/constants"
+	"github.com/ktny/bubbletea-learning/pkg/storage"
+)
+
+// 保存するTODOリストの状態
+type todoListSnapshot struct {
+	items    []todoItem
+	sortMode todoSortMode // 並び順（保存できないストアでは無視する）
+}
+
+// TODOリストの保存先を抽象化するインターフェース
+type todoStore interface {
+	// Load - 保存済みのアイテムと並び順を読み込む（未保存の場合は fs.ErrNotExist を返す）
+	Load() (todoListSnapshot, error)
+	// Save - アイテム一覧と並び順を保存する
+	Save(snapshot todoListSnapshot) error
+}
+
+// JSONファイルのフォーマットバージョン
+const todoFileVersion = 1
+
+// JSONファイル上の表現
+type todoFile struct {
+	Version int          `json:"version"`
+	Sort    string       `json:"sort,omitempty"` // 並び順（省略時は優先度順）
+	Items   []todoRecord `json:"items"`
+}
+
+// JSONファイル上のTODOアイテム
+type todoRecord struct {
+	Title     string   `json:"title"`
+	Completed bool     `json:"completed"`
+	Done      string   `json:"done,omitempty"` // RFC 3339
+	Due       string   `json:"due,omitempty"`  // YYYY-MM-DD
+	Priority  string   `json:"priority,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Note      string   `json:"note,omitempty"`
+	Created   string   `json:"created,omitempty"` // RFC 3339
+	Depth     int      `json:"depth,omitempty"`   // サブタスクの階層
+	Collapsed bool     `json:"collapsed,omitempty"`
+}
+
+// アイテムをJSON上の表現に変換
+func newTodoRecord(item todoItem) todoRecord {
+	r := todoRecord{
+		Title:     item.title,
+		Completed: item.completed,
+		Priority:  item.priority.String(),
+		Tags:      item.tags,
+		Note:      item.note,
+		Depth:     item.depth,
+		Collapsed: item.collapsed,
+	}
+	if !item.due.IsZero() {
+		r.Due = item.due.Format(dueDateLayout)
+	}
+	if !item.created.IsZero() {
+		r.Created = item.created.Format(time.RFC3339)
+	}
+	if !item.done.IsZero() {
+		r.Done = item.done.Format(time.RFC3339)
+	}
+	return r
+}
+
+// JSON上の表現からアイテムに変換
+func (r todoRecord) toItem() (todoItem, error) {
+	item := todoItem{
+		title:     r.Title,
+		completed: r.Completed,
+		priority:  parsePriority(r.Priority),
+		tags:      r.Tags,
+		note:      r.Note,
+		depth:     r.Depth,
+		collapsed: r.Collapsed,
+	}
+	if r.Due != "" {
+		due, err := time.ParseInLocation(dueDateLayout, r.Due, time.Local)
+		if err != nil {
+			return todoItem{}, fmt.Errorf("期日の形式が不正です (%s): %w", r.Title, err)
+		}
+		item.due = due
+	}
+	if r.Created != "" {
+		created, err := time.Parse(time.RFC3339, r.Created)
+		if err != nil {
+			return todoItem{}, fmt.Errorf("作成日時の形式が不正です (%s): %w", r.Title, err)
+		}
+		item.created = created
+	}
+	if r.Done != "" {
+		done, err := time.Parse(time.RFC3339, r.Done)
+		if err != nil {
+			return todoItem{}, fmt.Errorf("完了日時の形式が不正です (%s): %w", r.Title, err)
+		}
+		item.done = done
+	}
+	return item, nil
+}
+
+// JSONファイルに保存するストア
+type jsonTodoStore struct {
+	path string
+}
+
+// コンストラクタ
+func newJSONTodoStore(path string) *jsonTodoStore {
+	return &jsonTodoStore{path: path}
+}
+
+// XDGデータディレクトリ配下のデフォルトのストア
+func defaultTodoStore() (*jsonTodoStore, error) {
+	path, err := storage.DataPath(constants.TodoFileName)
+	if err != nil {
+		return nil, err
+	}
+	return newJSONTodoStore(path), nil
+}
+
+// Load - JSONファイルから読み込み
+func (s *jsonTodoStore) Load() (todoListSnapshot, error) {
+	var file todoFile
+	if err := storage.LoadJSON(s.path, &file); err != nil {
+		return todoListSnapshot{}, err
+	}
+
+	snapshot := todoListSnapshot{
+		items:    make([]todoItem, 0, len(file.Items)),
+		sortMode: parseSortMode(file.Sort),
+	}
+	for _, r := range file.Items {
+		item, err := r.toItem()
+		if err != nil {
+			return todoListSnapshot{}, err
+		}
+		snapshot.items = append(snapshot.items, item)
+	}
+	return snapshot, nil
+}
+
+// Save - 一時ファイルへ書き込んでからリネームする
+func (s *jsonTodoStore) Save(snapshot todoListSnapshot) error {
+	file := todoFile{
+		Version: todoFileVersion,
+		Items:   make([]todoRecord, 0, len(snapshot.items)),
+	}
+	if snapshot.sortMode != sortByPriority {
+		file.Sort = todoSortModeKeys[snapshot.sortMode]
+	}
+	for _, item := range snapshot.items {
+		file.Items = append(file.Items, newTodoRecord(item))
+	}
+	return storage.SaveJSON(s.path, file)
+}
+
+// 読み込み完了メッセージ
+type todoLoadedMsg struct {
+	snapshot todoListSnapshot
+	err      error
+}
+
+// ストアから読み込むコマンド
+func loadTodosCmd(store todoStore) tea.Cmd {
+	return func() tea.Msg {
+		snapshot, err := store.Load()
+		return todoLoadedMsg{snapshot: snapshot, err: err}
+	}
+}
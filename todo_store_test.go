@@ -0,0 +1,257 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// テスト用のメモリ上のストア
+type memoryTodoStore struct {
+	items   []todoItem
+	saved   int
+	loadErr error
+	saveErr error
+}
+
+func (s *memoryTodoStore) Load() (todoListSnapshot, error) {
+	if s.loadErr != nil {
+		return todoListSnapshot{}, s.loadErr
+	}
+	return todoListSnapshot{items: append([]todoItem(nil), s.items...)}, nil
+}
+
+func (s *memoryTodoStore) Save(snapshot todoListSnapshot) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.items = append([]todoItem(nil), snapshot.items...)
+	s.saved++
+	return nil
+}
+
+// 読み込みが完了したストア付きのモデル（未保存として初期データのまま始める）
+func newLoadedTodoModel(store todoStore) todoModel {
+	return NewTodoModelWithStore(store).handleLoaded(todoLoadedMsg{err: fs.ErrNotExist})
+}
+
+func TestJSONTodoStore(t *testing.T) {
+	t.Run("保存と読み込み", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "todo.json")
+		store := newJSONTodoStore(path)
+		items := []todoItem{
+			{title: "牛乳を買う", completed: false},
+			{title: "レビューする", completed: true},
+		}
+
+		if err := store.Save(todoListSnapshot{items: items, sortMode: sortByDue}); err != nil {
+			t.Fatalf("保存でエラーが発生すべきでない: %v", err)
+		}
+
+		loaded, err := store.Load()
+		if err != nil {
+			t.Fatalf("読み込みでエラーが発生すべきでない: %v", err)
+		}
+		if len(loaded.items) != len(items) {
+			t.Fatalf("読み込んだアイテム数は%dであるべき、実際: %d", len(items), len(loaded.items))
+		}
+		for i := range items {
+			if !reflect.DeepEqual(loaded.items[i], items[i]) {
+				t.Errorf("アイテム%dが一致しない: 期待 %+v, 実際 %+v", i, items[i], loaded.items[i])
+			}
+		}
+		if loaded.sortMode != sortByDue {
+			t.Errorf("並び順が保持されるべき、実際: %v", loaded.sortMode)
+		}
+	})
+
+	t.Run("ファイルが存在しない場合", func(t *testing.T) {
+		store := newJSONTodoStore(filepath.Join(t.TempDir(), "missing.json"))
+
+		_, err := store.Load()
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fs.ErrNotExistが返されるべき、実際: %v", err)
+		}
+	})
+
+	t.Run("ディレクトリが自動作成される", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nested", "dir", "todo.json")
+		store := newJSONTodoStore(path)
+
+		if err := store.Save(todoListSnapshot{items: []todoItem{{title: "テスト"}}}); err != nil {
+			t.Fatalf("保存でエラーが発生すべきでない: %v", err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("ファイルが作成されるべき: %v", err)
+		}
+	})
+
+	t.Run("一時ファイルが残らない", func(t *testing.T) {
+		dir := t.TempDir()
+		store := newJSONTodoStore(filepath.Join(dir, "todo.json"))
+
+		for i := 0; i < 3; i++ {
+			if err := store.Save(todoListSnapshot{items: []todoItem{{title: "テスト"}}}); err != nil {
+				t.Fatalf("保存でエラーが発生すべきでない: %v", err)
+			}
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("ディレクトリにはtodo.jsonのみが存在すべき、実際: %d個", len(entries))
+		}
+	})
+
+	t.Run("壊れたJSONはエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "todo.json")
+		if err := os.WriteFile(path, []byte("{broken"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := newJSONTodoStore(path).Load()
+		if err == nil {
+			t.Error("壊れたJSONではエラーが返されるべき")
+		}
+	})
+
+	t.Run("XDG_DATA_HOMEを使用", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_DATA_HOME", dir)
+
+		store, err := defaultTodoStore()
+		if err != nil {
+			t.Fatalf("エラーが発生すべきでない: %v", err)
+		}
+		expected := filepath.Join(dir, "bubbletea-learning", "todo.json")
+		if store.path != expected {
+			t.Errorf("保存先は%sであるべき、実際: %s", expected, store.path)
+		}
+	})
+
+	t.Run("並び順の保存と読み込み", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "todo.json")
+		m := newLoadedTodoModel(newJSONTodoStore(path))
+		m.items = []todoItem{{title: "B"}, {title: "A"}}
+
+		m = sendTodoKey(m, runeKey("J"))
+
+		reloaded := NewTodoModelWithStore(newJSONTodoStore(path))
+		reloaded = reloaded.handleLoaded(reloaded.Init()().(todoLoadedMsg))
+		if reloaded.sortMode != sortManual {
+			t.Errorf("手動の並び順が保存されるべき、実際: %v", reloaded.sortMode)
+		}
+		if titles := visibleTitles(reloaded); !reflect.DeepEqual(titles, []string{"A", "B"}) {
+			t.Errorf("手動で並べた順に読み込まれるべき、実際: %v", titles)
+		}
+	})
+}
+
+func TestTodoModelPersistence(t *testing.T) {
+	t.Run("ストア付きのInitは読み込みコマンドを返す", func(t *testing.T) {
+		store := &memoryTodoStore{items: []todoItem{{title: "保存済み"}}}
+		m := NewTodoModelWithStore(store)
+
+		cmd := m.Init()
+		if cmd == nil {
+			t.Fatal("Initは読み込みコマンドを返すべき")
+		}
+
+		newModel, _ := m.Update(cmd())
+		updatedModel := newModel.(todoModel)
+		if len(updatedModel.items) != 1 || updatedModel.items[0].title != "保存済み" {
+			t.Errorf("保存済みのアイテムが読み込まれるべき、実際: %+v", updatedModel.items)
+		}
+	})
+
+	t.Run("未保存の場合は初期データのまま", func(t *testing.T) {
+		m := NewTodoModelWithStore(&memoryTodoStore{loadErr: fs.ErrNotExist})
+		initialCount := len(m.items)
+
+		newModel, _ := m.Update(m.Init()())
+		updatedModel := newModel.(todoModel)
+		if len(updatedModel.items) != initialCount {
+			t.Errorf("初期データが保持されるべき、実際: %d件", len(updatedModel.items))
+		}
+		if updatedModel.err != nil {
+			t.Errorf("未保存はエラーとして扱わないべき、実際: %v", updatedModel.err)
+		}
+	})
+
+	t.Run("読み込みエラーの表示", func(t *testing.T) {
+		m := NewTodoModelWithStore(&memoryTodoStore{loadErr: errors.New("読み込み失敗")})
+
+		newModel, _ := m.Update(m.Init()())
+		updatedModel := newModel.(todoModel)
+		if updatedModel.err == nil {
+			t.Fatal("読み込みエラーが保持されるべき")
+		}
+		if !contains(updatedModel.View(), "読み込み失敗") {
+			t.Error("ビューにエラーが表示されるべき")
+		}
+	})
+
+	t.Run("切り替え時に保存される", func(t *testing.T) {
+		store := &memoryTodoStore{}
+		m := newLoadedTodoModel(store)
+		initialState := m.items[0].completed
+
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		if store.saved != 1 {
+			t.Fatalf("1回保存されるべき、実際: %d回", store.saved)
+		}
+		if store.items[0].completed == initialState {
+			t.Error("切り替え後の状態が保存されるべき")
+		}
+	})
+
+	t.Run("保存エラーの表示", func(t *testing.T) {
+		m := newLoadedTodoModel(&memoryTodoStore{saveErr: errors.New("書き込み失敗")})
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeySpace})
+		updatedModel := newModel.(todoModel)
+		if updatedModel.err == nil {
+			t.Error("保存エラーが保持されるべき")
+		}
+	})
+
+	t.Run("読み込みが終わるまで操作しない", func(t *testing.T) {
+		store := &memoryTodoStore{items: []todoItem{{title: "保存済み"}}}
+		m := NewTodoModelWithStore(store)
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+		if store.saved != 0 || store.items[0].title != "保存済み" {
+			t.Fatalf("読み込み前の初期データで上書きしないべき、実際: %d回 %+v", store.saved, store.items)
+		}
+		if !contains(m.View(), "読み込み中") {
+			t.Error("読み込み中であることを表示するべき")
+		}
+
+		newModel, _ := m.Update(m.Init()())
+		m = sendTodoKey(newModel.(todoModel), tea.KeyMsg{Type: tea.KeyEnter})
+		if store.saved != 1 || !store.items[0].completed {
+			t.Errorf("読み込み後は操作できるべき、実際: %+v", store.items)
+		}
+	})
+
+	t.Run("カーソル移動では保存しない", func(t *testing.T) {
+		store := &memoryTodoStore{}
+		m := newLoadedTodoModel(store)
+
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+		if store.saved != 0 {
+			t.Errorf("カーソル移動では保存されないべき、実際: %d回", store.saved)
+		}
+	})
+}
@@ -180,6 +180,34 @@ func TestTodoModel(t *testing.T) {
 		}
 	})
 
+	t.Run("ビューポートの調整（メモ・入力欄の行を数える）", func(t *testing.T) {
+		m := newTodoTestModel(
+			todoItem{title: "A"},
+			todoItem{title: "B"},
+			todoItem{title: "C", note: "1行目\n2行目"},
+			todoItem{title: "D"},
+		)
+		m.sortMode = sortManual
+		m.height = 3
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		if m.viewport != 2 || !contains(m.View(), "C") || !contains(m.View(), "2行目") {
+			t.Errorf("メモの2行を含めてカーソルの行が見えるべき: viewport=%d\n%s", m.viewport, m.View())
+		}
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown})
+		if m.viewport != 0 {
+			t.Fatalf("メモがなければ3件表示するべき: viewport=%d", m.viewport)
+		}
+		m = sendTodoKey(m, runeKey("n"))
+		if m.viewport != 1 || !contains(m.View(), "B") {
+			t.Errorf("メモの入力欄を開いてもカーソルの行が見えるべき: viewport=%d\n%s", m.viewport, m.View())
+		}
+	})
+
 	t.Run("ウィンドウサイズ変更の処理", func(t *testing.T) {
 		m := NewTodoModel()
 		msg := tea.WindowSizeMsg{Width: 80, Height: 24}
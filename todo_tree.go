@@ -0,0 +1,279 @@
+package main
+
+import "slices"
+
+// サブタスクは items 上で親の直後に depth+1 で並べて表現する。
+// そのため、あるアイテムとその子孫は常に連続した範囲になる。
+
+// 各アイテムの親のインデックス（ルートは-1）
+func (m todoModel) parents() []int {
+	parents := make([]int, len(m.items))
+	var stack []int // 祖先のインデックス
+	for i, item := range m.items {
+		for len(stack) > 0 && m.items[stack[len(stack)-1]].depth >= item.depth {
+			stack = stack[:len(stack)-1]
+		}
+		parents[i] = -1
+		if len(stack) > 0 {
+			parents[i] = stack[len(stack)-1]
+		}
+		stack = append(stack, i)
+	}
+	return parents
+}
+
+// アイテムと子孫の範囲の終端（この位置は含まない）
+func (m todoModel) subtreeEnd(index int) int {
+	end := index + 1
+	for end < len(m.items) && m.items[end].depth > m.items[index].depth {
+		end++
+	}
+	return end
+}
+
+// 子孫のうち完了済みの数と総数
+func (m todoModel) progress(index int) (done, total int) {
+	for _, item := range m.items[index+1 : m.subtreeEnd(index)] {
+		total++
+		if item.completed {
+			done++
+		}
+	}
+	return done, total
+}
+
+// 子を持つか
+func (m todoModel) hasChildren(index int) bool {
+	return index+1 < len(m.items) && m.items[index+1].depth > m.items[index].depth
+}
+
+// 表示する行（絞り込み後、兄弟の中で並び順に並べ、折りたたまれた子孫を除いたインデックス）
+func (m todoModel) rows() []int {
+	parents := m.parents()
+
+	// 条件に一致するアイテムと、その祖先を表示する
+	show := make([]bool, len(m.items))
+	for i := range m.items {
+		show[i] = m.visible(i)
+	}
+	for i := len(m.items) - 1; i >= 0; i-- {
+		if show[i] && parents[i] >= 0 {
+			show[parents[i]] = true
+		}
+	}
+
+	children := make(map[int][]int)
+	for i, p := range parents {
+		if show[i] {
+			children[p] = append(children[p], i)
+		}
+	}
+
+	var rows []int
+	var walk func(parent int)
+	walk = func(parent int) {
+		siblings := children[parent]
+		slices.SortStableFunc(siblings, func(a, b int) int {
+			return m.sortMode.compare(m.items[a], m.items[b])
+		})
+		for _, i := range siblings {
+			rows = append(rows, i)
+			if !m.items[i].collapsed {
+				walk(i)
+			}
+		}
+	}
+	walk(-1)
+	return rows
+}
+
+// 折りたたむ（既に折りたたまれているか子がない場合は親へ移動）
+func (m todoModel) collapseOrParent() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	if m.hasChildren(i) && !m.items[i].collapsed {
+		m.items[i].collapsed = true
+		return m.clampViewport()
+	}
+	if p := m.parents()[i]; p >= 0 {
+		return m.cursorToItem(p)
+	}
+	return m
+}
+
+// 展開する（既に展開されている場合は最初の子へ移動）
+func (m todoModel) expandOrChild() todoModel {
+	i := m.currentIndex()
+	if i < 0 || !m.hasChildren(i) {
+		return m
+	}
+	if m.items[i].collapsed {
+		m.items[i].collapsed = false
+		return m
+	}
+	return m.moveCursorDown()
+}
+
+// 子孫も含めて完了状態を切り替え
+func (m todoModel) toggleWithChildren() todoModel {
+	i := m.currentIndex()
+	if i < 0 {
+		return m
+	}
+	completed := !m.items[i].completed
+	end := m.subtreeEnd(i)
+	for j := i; j < end; j++ {
+		m.items[j] = m.items[j].withCompleted(completed, m.now())
+	}
+	return m.clampCursor()
+}
+
+// 直前の兄弟の子にする
+func (m todoModel) indentItem() todoModel {
+	i := m.currentIndex()
+	if i <= 0 || m.items[i-1].depth < m.items[i].depth {
+		// 直前に兄弟がない
+		return m
+	}
+
+	// 新しい親（直前の兄弟）を展開して見えるようにする
+	sibling := i - 1
+	for m.items[sibling].depth > m.items[i].depth {
+		sibling--
+	}
+	m.items[sibling].collapsed = false
+
+	end := m.subtreeEnd(i)
+	for j := i; j < end; j++ {
+		m.items[j].depth++
+	}
+	return m.cursorToItem(i)
+}
+
+// 親の兄弟にする（親の子孫の後ろへ移動）
+func (m todoModel) outdentItem() todoModel {
+	i := m.currentIndex()
+	if i < 0 || m.items[i].depth == 0 {
+		return m
+	}
+	parent := m.parents()[i]
+	end := m.subtreeEnd(i)
+	parentEnd := m.subtreeEnd(parent)
+
+	block := slices.Clone(m.items[i:end])
+	for j := range block {
+		block[j].depth--
+	}
+	items := slices.Delete(slices.Clone(m.items), i, end)
+	index := parentEnd - len(block)
+	m.items = slices.Insert(items, index, block...)
+	return m.cursorToItem(index)
+}
+
+// 表示中の並び順を items の順に反映する（手動の並び順に切り替える前に使う）
+func (m todoModel) applySortOrder() todoModel {
+	parents := m.parents()
+	children := make(map[int][]int)
+	for i, p := range parents {
+		children[p] = append(children[p], i)
+	}
+
+	// rows() と同じ順で、非表示のアイテムも含めて並べ直す
+	items := make([]todoItem, 0, len(m.items))
+	var walk func(parent int)
+	walk = func(parent int) {
+		siblings := children[parent]
+		slices.SortStableFunc(siblings, func(a, b int) int {
+			return m.sortMode.compare(m.items[a], m.items[b])
+		})
+		for _, i := range siblings {
+			items = append(items, m.items[i])
+			walk(i)
+		}
+	}
+	walk(-1)
+	m.items = items
+	m.sortMode = sortManual
+	return m
+}
+
+// カーソル位置のアイテムを表示中の兄弟と入れ替えて上へ移動
+func (m todoModel) moveItemUp() todoModel {
+	return m.moveItem(-1)
+}
+
+// カーソル位置のアイテムを表示中の兄弟と入れ替えて下へ移動
+func (m todoModel) moveItemDown() todoModel {
+	return m.moveItem(1)
+}
+
+// カーソル位置のアイテムを子孫ごと dir の方向の兄弟の反対側へ移動
+func (m todoModel) moveItem(dir int) todoModel {
+	if m.currentIndex() < 0 {
+		return m
+	}
+	if m.sortMode != sortManual {
+		// 見た目の順を保ったまま手動の並び順に切り替える
+		// （表示順は変わらないのでカーソルの行もそのまま）
+		m = m.applySortOrder()
+	}
+
+	i := m.currentIndex()
+	parents := m.parents()
+	rows := m.rows()
+	row := slices.Index(rows, i)
+	// 絞り込みで隠れている兄弟は飛ばして、表示中の兄弟と入れ替える
+	sibling := -1
+	for r := row + dir; r >= 0 && r < len(rows); r += dir {
+		if parents[rows[r]] == parents[i] {
+			sibling = rows[r]
+			break
+		}
+		if m.items[rows[r]].depth < m.items[i].depth {
+			// 親の範囲を出た
+			break
+		}
+	}
+	if sibling < 0 {
+		return m
+	}
+
+	// Markdownの見出しなどはアイテムと一緒に動かさず、元の位置に残す
+	from := min(i, sibling)
+	to := max(m.subtreeEnd(i), m.subtreeEnd(sibling))
+	m.items = slices.Clone(m.items)
+	leading := make([][]string, to-from)
+	for j := from; j < to; j++ {
+		leading[j-from], m.items[j].leading = m.items[j].leading, nil
+	}
+
+	block := slices.Clone(m.items[i:m.subtreeEnd(i)])
+	index := sibling // 上へは兄弟の前へ
+	if dir > 0 {
+		// 下へは兄弟（と子孫）の後ろへ
+		index = m.subtreeEnd(sibling) - len(block)
+	}
+	m.items = slices.Delete(m.items, i, i+len(block))
+	m.items = slices.Insert(m.items, index, block...)
+
+	for j := from; j < to; j++ {
+		m.items[j].leading = leading[j-from]
+	}
+	return m.cursorToItem(index)
+}
+
+// 読み込んだ depth を整合性のある値に直す
+func normalizeDepths(items []todoItem) {
+	prev := -1
+	for i := range items {
+		if items[i].depth < 0 {
+			items[i].depth = 0
+		}
+		if items[i].depth > prev+1 {
+			items[i].depth = prev + 1
+		}
+		prev = items[i].depth
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 階層付きのテスト用モデル
+//
+//	親A
+//	  子A1
+//	    孫A1a
+//	  子A2
+//	親B
+func newTodoTreeModel() todoModel {
+	return newTodoTestModel(
+		todoItem{title: "親A"},
+		todoItem{title: "子A1", depth: 1},
+		todoItem{title: "孫A1a", depth: 2},
+		todoItem{title: "子A2", depth: 1, completed: true},
+		todoItem{title: "親B"},
+	)
+}
+
+// アイテムのタイトルと階層の一覧
+func treeOutline(m todoModel) []string {
+	var lines []string
+	for _, item := range m.items {
+		lines = append(lines, strings.Repeat("-", item.depth)+item.title)
+	}
+	return lines
+}
+
+func TestTodoTree(t *testing.T) {
+	t.Run("親のインデックス", func(t *testing.T) {
+		m := newTodoTreeModel()
+		expected := []int{-1, 0, 1, 0, -1}
+		if parents := m.parents(); !reflect.DeepEqual(parents, expected) {
+			t.Errorf("parents() = %v, 期待値: %v", parents, expected)
+		}
+	})
+
+	t.Run("子孫の範囲と進捗", func(t *testing.T) {
+		m := newTodoTreeModel()
+		if end := m.subtreeEnd(0); end != 4 {
+			t.Errorf("親Aの子孫の終端は4であるべき、実際: %d", end)
+		}
+		if end := m.subtreeEnd(4); end != 5 {
+			t.Errorf("親Bの子孫の終端は5であるべき、実際: %d", end)
+		}
+		done, total := m.progress(0)
+		if done != 1 || total != 3 {
+			t.Errorf("親Aの進捗は1/3であるべき、実際: %d/%d", done, total)
+		}
+	})
+
+	t.Run("兄弟の中で並び替える", func(t *testing.T) {
+		m := newTodoTestModel(
+			todoItem{title: "親A"},
+			todoItem{title: "子A1", depth: 1},
+			todoItem{title: "子A2", depth: 1, priority: priorityHigh},
+			todoItem{title: "親B", priority: priorityLow},
+		)
+		expected := []string{"親B", "親A", "子A2", "子A1"}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, expected) {
+			t.Errorf("表示順が正しくない: 期待 %v, 実際 %v", expected, titles)
+		}
+	})
+
+	t.Run("hキーで折りたたみ、もう一度で親へ移動", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.cursor = 1 // 子A1
+
+		m = sendTodoKey(m, runeKey("h"))
+		if !m.items[1].collapsed {
+			t.Fatal("子A1が折りたたまれるべき")
+		}
+		expected := []string{"親A", "子A1", "子A2", "親B"}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, expected) {
+			t.Errorf("孫が非表示になるべき: 期待 %v, 実際 %v", expected, titles)
+		}
+
+		m = sendTodoKey(m, runeKey("h"))
+		if m.items[m.currentIndex()].title != "親A" {
+			t.Errorf("カーソルは親Aに移動するべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+	})
+
+	t.Run("lキーで展開、もう一度で子へ移動", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].collapsed = true
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyRight})
+		if m.items[0].collapsed {
+			t.Fatal("親Aが展開されるべき")
+		}
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyRight})
+		if m.items[m.currentIndex()].title != "子A1" {
+			t.Errorf("カーソルは子A1に移動するべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+	})
+
+	t.Run("←キーで折りたたみ", func(t *testing.T) {
+		m := newTodoTreeModel()
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyLeft})
+
+		if !m.items[0].collapsed {
+			t.Error("親Aが折りたたまれるべき")
+		}
+		if len(m.rows()) != 2 {
+			t.Errorf("表示中の行は2であるべき、実際: %d", len(m.rows()))
+		}
+	})
+
+	t.Run("スクロールは表示中の行で数える", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].collapsed = true
+		m.height = 1
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown})
+
+		if m.items[m.currentIndex()].title != "親B" {
+			t.Errorf("折りたたまれた子孫を飛ばして親Bに移動するべき、実際: %s", m.items[m.currentIndex()].title)
+		}
+		if m.viewport != 1 {
+			t.Errorf("ビューポートは表示中の行で1であるべき、実際: %d", m.viewport)
+		}
+	})
+
+	t.Run("Aキーでサブタスクを追加", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].collapsed = true
+
+		m = sendTodoKey(m, runeKey("A"))
+		m = typeTodoText(m, "子A3")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		expected := []string{"親A", "-子A1", "--孫A1a", "-子A2", "-子A3", "親B"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("最後の子として追加されるべき: 期待 %v, 実際 %v", expected, outline)
+		}
+		if m.items[0].collapsed {
+			t.Error("追加先の親は展開されるべき")
+		}
+	})
+
+	t.Run("aキーは子孫の後ろに兄弟として追加", func(t *testing.T) {
+		m := newTodoTreeModel()
+
+		m = sendTodoKey(m, runeKey("a"))
+		m = typeTodoText(m, "親C")
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+		expected := []string{"親A", "-子A1", "--孫A1a", "-子A2", "親C", "親B"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("子孫を奪わずに追加されるべき: 期待 %v, 実際 %v", expected, outline)
+		}
+	})
+
+	t.Run("ddで子孫ごと削除し、uで元に戻す", func(t *testing.T) {
+		m := newTodoTreeModel()
+		original := treeOutline(m)
+
+		m = sendTodoKey(m, runeKey("d"))
+		m = sendTodoKey(m, runeKey("d"))
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, []string{"親B"}) {
+			t.Errorf("子孫ごと削除されるべき、実際: %v", outline)
+		}
+
+		m = sendTodoKey(m, runeKey("u"))
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, original) {
+			t.Errorf("子孫ごと元に戻るべき: 期待 %v, 実際 %v", original, outline)
+		}
+	})
+
+	t.Run("xキーで子孫ごと完了", func(t *testing.T) {
+		m := newTodoTreeModel()
+
+		m = sendTodoKey(m, runeKey("x"))
+
+		for i := 0; i < 4; i++ {
+			if !m.items[i].completed {
+				t.Errorf("%sは完了になるべき", m.items[i].title)
+			}
+		}
+		if m.items[4].completed {
+			t.Error("親Bは変更されないべき")
+		}
+
+		m = sendTodoKey(m, runeKey("x"))
+		for i := 0; i < 4; i++ {
+			if m.items[i].completed {
+				t.Errorf("%sは未完了に戻るべき", m.items[i].title)
+			}
+		}
+	})
+
+	t.Run("スペースキーは親のみ切り替え", func(t *testing.T) {
+		m := newTodoTreeModel()
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeySpace})
+
+		if !m.items[0].completed || m.items[1].completed {
+			t.Error("スペースキーでは親のみ切り替わるべき")
+		}
+	})
+
+	t.Run(">キーで直前の兄弟の子にする", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].collapsed = true
+		m.cursor = 1 // 親B
+
+		m = sendTodoKey(m, runeKey(">"))
+
+		expected := []string{"親A", "-子A1", "--孫A1a", "-子A2", "-親B"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("親Aの子になるべき: 期待 %v, 実際 %v", expected, outline)
+		}
+		if m.items[0].collapsed {
+			t.Error("新しい親は展開されるべき")
+		}
+		if m.items[m.currentIndex()].title != "親B" {
+			t.Error("カーソルは移動したアイテムに留まるべき")
+		}
+	})
+
+	t.Run("直前に兄弟がない場合の>キー", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.cursor = 1 // 子A1（最初の子）
+
+		m = sendTodoKey(m, runeKey(">"))
+
+		if m.items[1].depth != 1 {
+			t.Error("最初の子は階層を下げられないべき")
+		}
+	})
+
+	t.Run("<キーで親の兄弟にする", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.cursor = 1 // 子A1
+
+		m = sendTodoKey(m, runeKey("<"))
+
+		expected := []string{"親A", "-子A2", "子A1", "-孫A1a", "親B"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("親Aの後ろに移動するべき: 期待 %v, 実際 %v", expected, outline)
+		}
+		if m.items[m.currentIndex()].title != "子A1" {
+			t.Error("カーソルは移動したアイテムに留まるべき")
+		}
+	})
+
+	t.Run("絞り込みで一致した子の祖先を表示", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.filterInput.SetValue("孫")
+
+		expected := []string{"親A", "子A1", "孫A1a"}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, expected) {
+			t.Errorf("祖先も表示されるべき: 期待 %v, 実際 %v", expected, titles)
+		}
+	})
+
+	t.Run("階層の正規化", func(t *testing.T) {
+		items := []todoItem{{depth: 1}, {depth: 3}, {depth: -1}, {depth: 1}}
+		normalizeDepths(items)
+
+		var depths []int
+		for _, item := range items {
+			depths = append(depths, item.depth)
+		}
+		if expected := []int{0, 1, 0, 1}; !reflect.DeepEqual(depths, expected) {
+			t.Errorf("階層は整合性のある値になるべき: 期待 %v, 実際 %v", expected, depths)
+		}
+	})
+
+	t.Run("階層の保存と読み込み", func(t *testing.T) {
+		store := newJSONTodoStore(filepath.Join(t.TempDir(), "todo.json"))
+		m := newTodoTreeModel()
+		m.items[1].collapsed = true
+
+		if err := store.Save(todoListSnapshot{items: m.items}); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := store.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.items, m.items) {
+			t.Errorf("階層と折りたたみ状態が保持されるべき: 期待 %+v, 実際 %+v", m.items, loaded.items)
+		}
+	})
+}
+
+func TestTodoTreeView(t *testing.T) {
+	t.Run("インデントと進捗の表示", func(t *testing.T) {
+		m := newTodoTreeModel()
+		view := m.View()
+
+		if !strings.Contains(view, "(1/3)") {
+			t.Error("親Aの進捗が表示されるべき")
+		}
+		if !strings.Contains(view, "▾") {
+			t.Error("展開中の記号が表示されるべき")
+		}
+		if !strings.Contains(view, "      [ ] 孫A1a") {
+			t.Error("孫は階層に応じてインデントされるべき")
+		}
+	})
+
+	t.Run("折りたたみの表示", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.items[0].collapsed = true
+		view := m.View()
+
+		if !strings.Contains(view, "▸") {
+			t.Error("折りたたみ中の記号が表示されるべき")
+		}
+		if strings.Contains(view, "孫A1a") {
+			t.Error("折りたたまれた子孫は表示されないべき")
+		}
+	})
+}
+
+func TestTodoModelReorder(t *testing.T) {
+	t.Run("Kキーで上へ移動し、カーソルが追従する", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.sortMode = sortManual
+		m.cursor = 2 // C
+
+		m = sendTodoKey(m, runeKey("K"))
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"A", "C", "B", "D", "E"}) {
+			t.Errorf("CがBの前に移動するべき、実際: %v", titles)
+		}
+		if m.cursor != 1 || m.items[m.currentIndex()].title != "C" {
+			t.Errorf("カーソルはCに留まるべき: cursor=%d", m.cursor)
+		}
+	})
+
+	t.Run("Alt+↓で下へ移動", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.sortMode = sortManual
+
+		m = sendTodoKey(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"B", "A", "C", "D", "E"}) {
+			t.Errorf("AがBの後ろに移動するべき、実際: %v", titles)
+		}
+		if m.cursor != 1 {
+			t.Errorf("カーソルは移動したAに追従するべき、実際: %d", m.cursor)
+		}
+	})
+
+	t.Run("端では移動しない", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.sortMode = sortManual
+
+		m = sendTodoKey(m, runeKey("K"))
+
+		if titles := visibleTitles(m); titles[0] != "A" {
+			t.Errorf("先頭のアイテムは移動しないべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("他の並び順から見た目の順を保って手動に切り替える", func(t *testing.T) {
+		m := newTodoTestModel(
+			todoItem{title: "低", priority: priorityLow},
+			todoItem{title: "高", priority: priorityHigh},
+			todoItem{title: "なし"},
+		)
+		m.cursor = 2 // なし
+
+		m = sendTodoKey(m, runeKey("K"))
+
+		if m.sortMode != sortManual {
+			t.Errorf("手動の並び順になるべき、実際: %v", m.sortMode)
+		}
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"高", "なし", "低"}) {
+			t.Errorf("表示順のまま入れ替わるべき、実際: %v", titles)
+		}
+		if !strings.Contains(m.View(), "並び順: 手動") {
+			t.Error("手動の並び順であることが表示されるべき")
+		}
+	})
+
+	t.Run("子孫ごと兄弟と入れ替える", func(t *testing.T) {
+		m := newTodoTreeModel()
+		m.sortMode = sortManual
+		m = m.cursorToItem(3) // 子A2
+
+		m = sendTodoKey(m, runeKey("K"))
+
+		expected := []string{"親A", "-子A2", "-子A1", "--孫A1a", "親B"}
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("子A1の子孫ごと入れ替わるべき: 期待 %v, 実際 %v", expected, outline)
+		}
+
+		m = sendTodoKey(m, runeKey("K"))
+		if outline := treeOutline(m); !reflect.DeepEqual(outline, expected) {
+			t.Errorf("親の範囲外には移動しないべき、実際: %v", outline)
+		}
+	})
+
+	t.Run("絞り込みで隠れた兄弟を飛ばす", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.sortMode = sortManual
+		m.hideCompleted = true
+		m.cursor = 1 // B
+
+		m = sendTodoKey(m, runeKey("J"))
+
+		if titles := visibleTitles(m); !reflect.DeepEqual(titles, []string{"A", "D", "B", "E"}) {
+			t.Errorf("表示中のDと入れ替わるべき、実際: %v", titles)
+		}
+	})
+
+	t.Run("ビューポートが追従する", func(t *testing.T) {
+		m := newTodoSelectModel()
+		m.sortMode = sortManual
+		m.height = 2
+		m = m.cursorToItem(1) // B
+
+		m = sendTodoKey(m, runeKey("J"))
+		m = sendTodoKey(m, runeKey("J"))
+
+		if m.cursor != 3 || m.viewport != 2 {
+			t.Errorf("カーソルが見えるようにスクロールするべき: cursor=%d viewport=%d", m.cursor, m.viewport)
+		}
+	})
+
+	t.Run("Markdownの見出しは元の位置に残る", func(t *testing.T) {
+		m := newTodoTestModel(
+			todoItem{title: "A", leading: []string{"# 見出し"}},
+			todoItem{title: "B"},
+		)
+		m.sortMode = sortManual
+
+		m = sendTodoKey(m, runeKey("J"))
+
+		if m.items[0].title != "B" || !reflect.DeepEqual(m.items[0].leading, []string{"# 見出し"}) {
+			t.Errorf("見出しは先頭に残るべき、実際: %+v", m.items)
+		}
+		if m.items[1].leading != nil {
+			t.Errorf("移動したアイテムに見出しが付かないべき、実際: %v", m.items[1].leading)
+		}
+	})
+}